@@ -0,0 +1,231 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// sortedByElements returns the subset indices sorted by the subsets'
+// elements (lexicographically), then by cost and then by index. Thus equal
+// subsets are adjacent and the cheapest of them is first.
+func sortedByElements(ins Instance) []int {
+	indices := make([]int, len(ins.Subsets))
+	for j := range indices {
+		indices[j] = j
+	}
+	slices.SortFunc(indices, func(lhs, rhs int) int {
+		if c := slices.Compare(ins.Subsets[lhs], ins.Subsets[rhs]); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(ins.Costs[lhs], ins.Costs[rhs]); c != 0 {
+			return c
+		}
+		return cmp.Compare(lhs, rhs)
+	})
+	return indices
+}
+
+// DuplicateSubsets returns the groups of subsets that have the same
+// elements. Each group has at least two subset indices ordered by cost and
+// then index, so the first one is kept by RemoveMoreExpensiveDuplicates. The
+// groups are ordered by their elements (lexicographically).
+func DuplicateSubsets(ins Instance) [][]int {
+	var groups [][]int
+	indices := sortedByElements(ins)
+	for k := 0; k < len(indices); {
+		end := k + 1
+		for end < len(indices) && slices.Equal(ins.Subsets[indices[k]], ins.Subsets[indices[end]]) {
+			end++
+		}
+		if end-k > 1 {
+			groups = append(groups, slices.Clone(indices[k:end]))
+		}
+		k = end
+	}
+	return groups
+}
+
+// RemoveMoreExpensiveDuplicates returns a copy of the instance where only the
+// cheapest subset of each group of subsets with the same elements is kept, see
+// DuplicateSubsets. The more expensive duplicates are never in an optimal
+// solution. The kept subsets are ordered by their elements
+// (lexicographically). The returned originalIndices maps the subset indices of
+// the copy to the subset indices of ins, i.e. the copy's subset j is ins's
+// subset originalIndices[j].
+func RemoveMoreExpensiveDuplicates(ins Instance) (reduced Instance, originalIndices []int) {
+	reduced = Instance{
+		ElementCount: ins.ElementCount,
+		Subsets:      make([][]int, 0, len(ins.Subsets)),
+		Costs:        make([]float64, 0, len(ins.Costs)),
+		ElementNames: ins.ElementNames,
+	}
+	if ins.SubsetNames != nil {
+		reduced.SubsetNames = make([]string, 0, len(ins.SubsetNames))
+	}
+	originalIndices = make([]int, 0, len(ins.Subsets))
+
+	indices := sortedByElements(ins)
+	for k, j := range indices {
+		if k > 0 && slices.Equal(ins.Subsets[indices[k-1]], ins.Subsets[j]) {
+			continue
+		}
+		reduced.Subsets = append(reduced.Subsets, ins.Subsets[j])
+		reduced.Costs = append(reduced.Costs, ins.Costs[j])
+		if ins.SubsetNames != nil {
+			reduced.SubsetNames = append(reduced.SubsetNames, ins.SubsetNames[j])
+		}
+		originalIndices = append(originalIndices, j)
+	}
+	return reduced, originalIndices
+}
+
+// CostSummary summarizes the subset costs.
+type CostSummary struct {
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	StdDev float64 // population standard deviation
+	// The number of distinct costs.
+	Distinct int
+	// If all costs are integers, which allows for stronger bounds.
+	Integral bool
+}
+
+// Analysis describes the structure of an instance. See Analyze.
+type Analysis struct {
+	ElementCount int
+	SubsetCount  int
+	// The number of element-subset incidences, i.e. the sum of the subset
+	// sizes.
+	Nonzeros int
+	// The fraction of the incidence matrix that is nonzero.
+	Density float64
+	// SubsetSizeHistogram[k] is the number of subsets with k elements.
+	SubsetSizeHistogram []int
+	// ElementFrequencyHistogram[k] is the number of elements in exactly k
+	// subsets.
+	ElementFrequencyHistogram []int
+	Costs                     CostSummary
+	// The groups of subsets with the same elements, see DuplicateSubsets.
+	DuplicateSubsets [][]int
+	// The number of subsets removed by RemoveMoreExpensiveDuplicates.
+	RedundantDuplicates int
+	// The elements in exactly one subset. That subset is in every exact
+	// cover.
+	SingletonElements []int
+	// The elements in no subset. If there are any, the instance is trivially
+	// infeasible.
+	UncoveredElements []int
+	// The connected components, see Components.
+	ComponentCount int
+	// The number of elements and subsets of the largest component by
+	// elements.
+	LargestComponentElements int
+	LargestComponentSubsets  int
+}
+
+// Analyze analyzes the structure of the instance. The instance should be
+// valid, see Validate.
+func Analyze(ins Instance) Analysis {
+	a := Analysis{ElementCount: ins.ElementCount, SubsetCount: len(ins.Subsets)}
+
+	frequencies := make([]int, ins.ElementCount)
+	for _, subset := range ins.Subsets {
+		a.Nonzeros += len(subset)
+		a.SubsetSizeHistogram = increment(a.SubsetSizeHistogram, len(subset))
+		for _, i := range subset {
+			frequencies[i]++
+		}
+	}
+	if a.ElementCount > 0 && a.SubsetCount > 0 {
+		a.Density = float64(a.Nonzeros) / (float64(a.ElementCount) * float64(a.SubsetCount))
+	}
+	for i, f := range frequencies {
+		a.ElementFrequencyHistogram = increment(a.ElementFrequencyHistogram, f)
+		switch f {
+		case 0:
+			a.UncoveredElements = append(a.UncoveredElements, i)
+		case 1:
+			a.SingletonElements = append(a.SingletonElements, i)
+		}
+	}
+
+	a.Costs = summarizeCosts(ins.Costs)
+	a.DuplicateSubsets = DuplicateSubsets(ins)
+	for _, group := range a.DuplicateSubsets {
+		a.RedundantDuplicates += len(group) - 1
+	}
+
+	components := Components(ins)
+	a.ComponentCount = len(components)
+	for _, c := range components {
+		if len(c.Elements) > a.LargestComponentElements {
+			a.LargestComponentElements = len(c.Elements)
+			a.LargestComponentSubsets = len(c.Subsets)
+		}
+	}
+	return a
+}
+
+// increment increments histogram[k], growing the histogram if needed.
+func increment(histogram []int, k int) []int {
+	for len(histogram) <= k {
+		histogram = append(histogram, 0)
+	}
+	histogram[k]++
+	return histogram
+}
+
+func summarizeCosts(costs []float64) CostSummary {
+	if len(costs) == 0 {
+		return CostSummary{}
+	}
+
+	sorted := slices.Clone(costs)
+	slices.Sort(sorted)
+	s := CostSummary{Min: sorted[0], Max: sorted[len(sorted)-1], Integral: true}
+	n := len(sorted)
+	if n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	total := 0.0
+	for k, c := range sorted {
+		total += c
+		if k == 0 || c != sorted[k-1] {
+			s.Distinct++
+		}
+		if c != math.Trunc(c) {
+			s.Integral = false
+		}
+	}
+	s.Mean = total / float64(n)
+
+	variance := 0.0
+	for _, c := range sorted {
+		variance += (c - s.Mean) * (c - s.Mean)
+	}
+	s.StdDev = math.Sqrt(variance / float64(n))
+	return s
+}
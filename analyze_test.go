@@ -0,0 +1,80 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"math"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDuplicateSubsets(t *testing.T) {
+	ins := Instance{
+		ElementCount: 2,
+		Subsets:      [][]int{{0, 1}, {0}, {1}, {1}, {0}, {0, 1}, {0}},
+		Costs:        []float64{13, 11, 7, 5, 3, 2, 3},
+		SubsetNames:  []string{"a", "b", "c", "d", "e", "f", "g"},
+	}
+	assert.DeepEqual(t, DuplicateSubsets(ins), [][]int{{4, 6, 1}, {5, 0}, {3, 2}})
+
+	reduced, originalIndices := RemoveMoreExpensiveDuplicates(ins)
+	assert.DeepEqual(t, reduced, Instance{
+		ElementCount: 2,
+		Subsets:      [][]int{{0}, {0, 1}, {1}},
+		Costs:        []float64{3, 2, 5},
+		SubsetNames:  []string{"e", "f", "d"},
+	})
+	assert.DeepEqual(t, originalIndices, []int{4, 5, 3})
+
+	assert.Assert(t, DuplicateSubsets(mpsTestInstance) == nil)
+}
+
+func TestAnalyze(t *testing.T) {
+	ins := Instance{
+		ElementCount: 5,
+		Subsets:      [][]int{{0, 1}, {1}, {2}, {0, 1}},
+		Costs:        []float64{2, 1, 3.5, 1.5},
+	}
+	a := Analyze(ins)
+	assert.DeepEqual(t, a, Analysis{
+		ElementCount:              5,
+		SubsetCount:               4,
+		Nonzeros:                  6,
+		Density:                   0.3,
+		SubsetSizeHistogram:       []int{0, 2, 2},
+		ElementFrequencyHistogram: []int{2, 1, 1, 1},
+		Costs: CostSummary{
+			Min:      1,
+			Max:      3.5,
+			Mean:     2,
+			Median:   1.75,
+			StdDev:   math.Sqrt((0 + 1 + 2.25 + 0.25) / 4.0),
+			Distinct: 4,
+		},
+		DuplicateSubsets:         [][]int{{3, 0}},
+		RedundantDuplicates:      1,
+		SingletonElements:        []int{2},
+		UncoveredElements:        []int{3, 4},
+		ComponentCount:           4,
+		LargestComponentElements: 2,
+		LargestComponentSubsets:  3,
+	})
+
+	assert.DeepEqual(t, Analyze(Instance{}), Analysis{})
+}
@@ -0,0 +1,346 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// The binary instance format stores the subsets as compressed columns,
+// similar to the compressed column matrix used by the solvers, where each
+// column ends with a sentinel. All integers are unsigned varints (see
+// encoding/binary) unless stated otherwise.
+//
+//	magic             4 bytes "\x89COV"
+//	version           currently 1
+//	flags             bit 0: element names, bit 1: subset names
+//	element count
+//	subset count
+//	nonzero count     the sum of the lengths of the subsets
+//	costs             one little endian float64 per subset
+//	columns           per subset: the differences between consecutive
+//	                  elements, where the first element e is stored as e+1,
+//	                  followed by the sentinel 0
+//	element names     if flagged: per element the length and the bytes
+//	subset names      if flagged: per subset the length and the bytes
+//	checksum          4 bytes little endian CRC-32 (IEEE) of all the bytes
+//	                  before it
+//
+// Since the elements of a subset are sorted and unique, every difference is
+// at least 1 and the sentinel 0 is unambiguous.
+
+package cover
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+var binaryMagic = []byte("\x89COV")
+
+const binaryVersion = 1
+
+const (
+	binaryElementNames = 1 << iota
+	binarySubsetNames
+)
+
+// ErrBinaryChecksum is returned by ReadBinary if the checksum does not match
+// the content, i.e. the content is corrupt.
+var ErrBinaryChecksum = errors.New("binary instance: checksum mismatch")
+
+// binaryWriter writes varints while computing the checksum. The first write
+// error is kept and later writes are skipped.
+type binaryWriter struct {
+	w   *bufio.Writer
+	crc hash.Hash32
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+func (bw *binaryWriter) write(b []byte) {
+	if bw.err != nil {
+		return
+	}
+	bw.crc.Write(b)
+	_, bw.err = bw.w.Write(b)
+}
+
+func (bw *binaryWriter) uvarint(x uint64) {
+	bw.write(binary.AppendUvarint(bw.buf[:0], x))
+}
+
+func (bw *binaryWriter) float64(x float64) {
+	bw.write(binary.LittleEndian.AppendUint64(bw.buf[:0], math.Float64bits(x)))
+}
+
+func (bw *binaryWriter) string(s string) {
+	bw.uvarint(uint64(len(s)))
+	bw.write([]byte(s))
+}
+
+// WriteBinary writes the instance in the binary format (see binary.go). The
+// instance must be valid, see Validate.
+func WriteBinary(w io.Writer, ins Instance) error {
+	if err := Validate(ins); err != nil {
+		return err
+	}
+
+	bw := binaryWriter{w: bufio.NewWriter(w), crc: crc32.NewIEEE()}
+	bw.write(binaryMagic)
+	bw.uvarint(binaryVersion)
+	flags := 0
+	if ins.ElementNames != nil {
+		flags |= binaryElementNames
+	}
+	if ins.SubsetNames != nil {
+		flags |= binarySubsetNames
+	}
+	bw.uvarint(uint64(flags))
+
+	nonzeros := 0
+	for _, subset := range ins.Subsets {
+		nonzeros += len(subset)
+	}
+	bw.uvarint(uint64(ins.ElementCount))
+	bw.uvarint(uint64(len(ins.Subsets)))
+	bw.uvarint(uint64(nonzeros))
+
+	for _, cost := range ins.Costs {
+		bw.float64(cost)
+	}
+	for _, subset := range ins.Subsets {
+		prev := -1
+		for _, e := range subset {
+			bw.uvarint(uint64(e - prev))
+			prev = e
+		}
+		bw.uvarint(0)
+	}
+	for _, name := range ins.ElementNames {
+		bw.string(name)
+	}
+	for _, name := range ins.SubsetNames {
+		bw.string(name)
+	}
+
+	if bw.err != nil {
+		return bw.err
+	}
+	if _, err := bw.w.Write(binary.LittleEndian.AppendUint32(nil, bw.crc.Sum32())); err != nil {
+		return err
+	}
+	return bw.w.Flush()
+}
+
+// maxPreallocation caps the capacity allocated up front from a count read
+// from a file. The counts are not trusted since they may be corrupt.
+const maxPreallocation = 1 << 20
+
+// binaryReader reads while computing the checksum of the bytes read.
+type binaryReader struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+}
+
+func (br *binaryReader) ReadByte() (byte, error) {
+	b, err := br.r.ReadByte()
+	if err == nil {
+		br.crc.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (br *binaryReader) readFull(b []byte) error {
+	if _, err := io.ReadFull(br.r, b); err != nil {
+		return err
+	}
+	br.crc.Write(b)
+	return nil
+}
+
+func (br *binaryReader) uvarint(what string) (uint64, error) {
+	x, err := binary.ReadUvarint(br)
+	if err != nil {
+		return 0, binaryReadError(what, err)
+	}
+	return x, nil
+}
+
+// count reads a count that must fit in an int.
+func (br *binaryReader) count(what string) (int, error) {
+	x, err := br.uvarint(what)
+	if err == nil && x > math.MaxInt32 {
+		err = fmt.Errorf("binary instance: the %s %d is too large", what, x)
+	}
+	return int(x), err
+}
+
+func (br *binaryReader) float64(what string) (float64, error) {
+	var b [8]byte
+	if err := br.readFull(b[:]); err != nil {
+		return 0, binaryReadError(what, err)
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+}
+
+func (br *binaryReader) string(what string) (string, error) {
+	n, err := br.count(what + " length")
+	if err != nil {
+		return "", err
+	}
+	// Copy instead of reading into a slice of length n so that a corrupt
+	// length can not cause a huge allocation.
+	var buf bytes.Buffer
+	buf.Grow(min(n, maxPreallocation))
+	if _, err := io.CopyN(&buf, io.TeeReader(br.r, br.crc), int64(n)); err != nil {
+		return "", binaryReadError(what, err)
+	}
+	return buf.String(), nil
+}
+
+func binaryReadError(what string, err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("binary instance: unable to read the %s: %w", what, err)
+}
+
+// ReadBinaryInstance reads an instance from a file in the binary format,
+// which may be compressed. See ReadBinary.
+func ReadBinaryInstance(filename string) (*Instance, error) {
+	return readInstanceFile(filename, ReadBinary)
+}
+
+// ReadBinary reads an instance in the binary format written by WriteBinary.
+// If the content is corrupt, ErrBinaryChecksum is returned unless the
+// corruption was found as a format error before the checksum was read.
+func ReadBinary(r io.Reader) (*Instance, error) {
+	br := binaryReader{r: bufio.NewReader(r), crc: crc32.NewIEEE()}
+
+	magic := make([]byte, len(binaryMagic))
+	if err := br.readFull(magic); err != nil || !bytes.Equal(magic, binaryMagic) {
+		return nil, errors.New("binary instance: the file does not start with the binary instance magic bytes")
+	}
+	version, err := br.uvarint("version")
+	if err != nil {
+		return nil, err
+	}
+	if version != binaryVersion {
+		return nil, fmt.Errorf("binary instance: version %d is not supported (only version %d)",
+			version, binaryVersion)
+	}
+	flags, err := br.uvarint("flags")
+	if err != nil {
+		return nil, err
+	}
+	if flags&^(binaryElementNames|binarySubsetNames) != 0 {
+		return nil, fmt.Errorf("binary instance: unknown flags %b", flags)
+	}
+
+	m, err := br.count("element count")
+	if err != nil {
+		return nil, err
+	}
+	n, err := br.count("subset count")
+	if err != nil {
+		return nil, err
+	}
+	nonzeros, err := br.count("nonzero count")
+	if err != nil {
+		return nil, err
+	}
+
+	// The counts are not trusted for allocations since they may be corrupt.
+	ins := Instance{
+		ElementCount: m,
+		Subsets:      make([][]int, 0, min(n, maxPreallocation)),
+		Costs:        make([]float64, 0, min(n, maxPreallocation)),
+	}
+	for j := 0; j < n; j++ {
+		cost, err := br.float64("cost")
+		if err != nil {
+			return nil, err
+		}
+		ins.Costs = append(ins.Costs, cost)
+	}
+
+	// The elements of all subsets share one backing array like a compressed
+	// column matrix.
+	elements := make([]int, 0, min(nonzeros, maxPreallocation))
+	for j := 0; j < n; j++ {
+		start := len(elements)
+		e := -1
+		for {
+			delta, err := br.uvarint("column")
+			if err != nil {
+				return nil, err
+			}
+			if delta == 0 {
+				break
+			}
+			if delta > uint64(m) || e+int(delta) >= m {
+				return nil, fmt.Errorf("binary instance: subset %d contains an element not in [0, %d)", j, m)
+			}
+			e += int(delta)
+			elements = append(elements, e)
+			if len(elements) > nonzeros {
+				return nil, fmt.Errorf("binary instance: more than the %d nonzeros stated", nonzeros)
+			}
+		}
+		ins.Subsets = append(ins.Subsets, elements[start:len(elements):len(elements)])
+	}
+	if len(elements) != nonzeros {
+		return nil, fmt.Errorf("binary instance: %d nonzeros read but %d stated", len(elements), nonzeros)
+	}
+
+	if flags&binaryElementNames != 0 {
+		ins.ElementNames = make([]string, 0, min(m, maxPreallocation))
+		for i := 0; i < m; i++ {
+			name, err := br.string("element name")
+			if err != nil {
+				return nil, err
+			}
+			ins.ElementNames = append(ins.ElementNames, name)
+		}
+	}
+	if flags&binarySubsetNames != 0 {
+		ins.SubsetNames = make([]string, 0, min(n, maxPreallocation))
+		for j := 0; j < n; j++ {
+			name, err := br.string("subset name")
+			if err != nil {
+				return nil, err
+			}
+			ins.SubsetNames = append(ins.SubsetNames, name)
+		}
+	}
+
+	sum := br.crc.Sum32()
+	var trailer [4]byte
+	if _, err := io.ReadFull(br.r, trailer[:]); err != nil {
+		return nil, binaryReadError("checksum", err)
+	}
+	if binary.LittleEndian.Uint32(trailer[:]) != sum {
+		return nil, ErrBinaryChecksum
+	}
+	if _, err := br.r.ReadByte(); err != io.EOF {
+		return nil, errors.New("binary instance: unexpected data after the checksum")
+	}
+	return &ins, nil
+}
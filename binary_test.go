@@ -0,0 +1,112 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	instances := []Instance{
+		{Subsets: [][]int{}, Costs: []float64{}},
+		mpsTestInstance,
+		MakeRandomInstance(20, 200, 1000, 5),
+	}
+	for _, ins := range instances {
+		var b bytes.Buffer
+		assert.NilError(t, WriteBinary(&b, ins))
+		read, err := ReadBinary(&b)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, *read, ins)
+	}
+}
+
+func TestBinaryIsCompact(t *testing.T) {
+	ins := MakeRandomInstance(20, 200, 1000, 5)
+	var b bytes.Buffer
+	assert.NilError(t, WriteBinary(&b, ins))
+	// As written by generate_instance.
+	js, err := json.MarshalIndent(ins, "", "  ")
+	assert.NilError(t, err)
+	assert.Assert(t, 3*b.Len() < len(js), "binary %d bytes, JSON %d bytes", b.Len(), len(js))
+}
+
+func TestReadBinaryErrors(t *testing.T) {
+	var b bytes.Buffer
+	assert.NilError(t, WriteBinary(&b, mpsTestInstance))
+	valid := b.Bytes()
+
+	corrupt := bytes.Clone(valid)
+	// Change the first cost's lowest byte.
+	corrupt[len(binaryMagic)+5] ^= 1
+	_, err := ReadBinary(bytes.NewReader(corrupt))
+	assert.Assert(t, errors.Is(err, ErrBinaryChecksum))
+
+	_, err = ReadBinary(bytes.NewReader(valid[:len(valid)-1]))
+	assert.ErrorContains(t, err, "unable to read the checksum")
+
+	_, err = ReadBinary(bytes.NewReader(append(bytes.Clone(valid), 0)))
+	assert.ErrorContains(t, err, "unexpected data after the checksum")
+
+	newer := bytes.Clone(valid)
+	newer[len(binaryMagic)] = binaryVersion + 1
+	_, err = ReadBinary(bytes.NewReader(newer))
+	assert.ErrorContains(t, err, "version 2 is not supported")
+
+	_, err = ReadBinary(bytes.NewReader([]byte("{}")))
+	assert.ErrorContains(t, err, "magic bytes")
+
+	// A corrupt name length must not be allocated up front.
+	var named bytes.Buffer
+	assert.NilError(t, WriteBinary(&named,
+		Instance{ElementCount: 1, Subsets: [][]int{{0}}, Costs: []float64{1}, ElementNames: []string{"R1"}}))
+	// Replace the name's length, the name and the checksum.
+	hugeName := binary.AppendUvarint(bytes.Clone(named.Bytes()[:named.Len()-4-1-2]), math.MaxInt32)
+	hugeName = append(hugeName, "R1"...)
+	_, err = ReadBinary(bytes.NewReader(hugeName))
+	assert.Assert(t, errors.Is(err, io.ErrUnexpectedEOF), "%v", err)
+	assert.ErrorContains(t, err, "unable to read the element name")
+
+	var invalid bytes.Buffer
+	assert.ErrorContains(t, WriteBinary(&invalid, Instance{ElementCount: 1, Subsets: [][]int{{1}}, Costs: []float64{1}}),
+		"not a member of [0, 1)")
+}
+
+func TestReadInstanceBinary(t *testing.T) {
+	var b bytes.Buffer
+	assert.NilError(t, WriteBinary(&b, mpsTestInstance))
+	dir := t.TempDir()
+	for _, name := range []string{"a.cover", "a.json"} {
+		// The magic bytes take precedence over the extension.
+		path := filepath.Join(dir, name)
+		assert.NilError(t, os.WriteFile(path, b.Bytes(), 0o600))
+		ins, err := ReadInstance(path)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, *ins, mpsTestInstance)
+	}
+}
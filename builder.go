@@ -0,0 +1,181 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Builder builds an Instance incrementally. Elements can be referred to
+// either by index or by name. Names are interned so the same name always
+// refers to the same element index. The elements of a subset may be given
+// in any order and may contain duplicates.
+//
+// Problems with the input are collected and all of them are reported by
+// Build instead of only the first one.
+//
+// The zero value is not usable. Use NewBuilder.
+type Builder struct {
+	elementCount   int
+	elementNames   []string
+	elementIndices map[string]int
+
+	subsets     [][]int
+	costs       []float64
+	subsetNames []string
+	// The number of subsets added with a name.
+	namedSubsetCount int
+	subsetIndices    map[string]int
+
+	errs []error
+}
+
+// NewBuilder returns an empty Builder with no elements and no subsets.
+func NewBuilder() *Builder {
+	return &Builder{
+		elementIndices: make(map[string]int),
+		subsetIndices:  make(map[string]int),
+	}
+}
+
+// Element returns the index of the element with the name. If no element has
+// the name yet, a new element is added. The name must not be empty.
+func (b *Builder) Element(name string) int {
+	if name == "" {
+		b.errs = append(b.errs, errors.New("element names must not be empty"))
+	}
+	if idx, found := b.elementIndices[name]; found {
+		return idx
+	}
+	// The new element gets the next unused index. Unnamed elements referred
+	// to by index before may make the names sparse, so pad them.
+	idx := b.elementCount
+	for len(b.elementNames) < idx {
+		b.elementNames = append(b.elementNames, "")
+	}
+	b.elementNames = append(b.elementNames, name)
+	b.elementIndices[name] = idx
+	b.elementCount++
+	return idx
+}
+
+// SetElementCount makes sure that the elements 0, ..., m-1 exist. This is
+// needed if some elements are not in any subset, which makes the instance
+// infeasible, but is still a valid instance.
+func (b *Builder) SetElementCount(m int) {
+	if m < 0 {
+		b.errs = append(b.errs, fmt.Errorf("the element count %d is negative", m))
+		return
+	}
+	b.elementCount = max(b.elementCount, m)
+}
+
+// AddSubset adds a subset of the elements with the indices and returns the
+// subset's index.
+func (b *Builder) AddSubset(cost float64, elements ...int) int {
+	subset := make([]int, len(elements))
+	copy(subset, elements)
+	for _, e := range subset {
+		if e >= b.elementCount {
+			b.elementCount = e + 1
+		}
+	}
+
+	b.subsets = append(b.subsets, subset)
+	b.costs = append(b.costs, cost)
+	b.subsetNames = append(b.subsetNames, "")
+	return len(b.subsets) - 1
+}
+
+// AddNamedSubset adds a subset with a name of the elements with the names and
+// returns the subset's index. Elements not seen before are added. The name
+// must not be empty.
+func (b *Builder) AddNamedSubset(name string, cost float64, elements ...string) int {
+	if name == "" {
+		b.errs = append(b.errs, errors.New("subset names must not be empty"))
+	}
+	indices := make([]int, 0, len(elements))
+	for _, e := range elements {
+		indices = append(indices, b.Element(e))
+	}
+
+	j := b.AddSubset(cost, indices...)
+	b.subsetNames[j] = name
+	b.namedSubsetCount++
+	if prev, found := b.subsetIndices[name]; found {
+		b.errs = append(b.errs, fmt.Errorf(
+			"the subset name %q is used by both subset %d and subset %d", name, prev, j))
+	} else {
+		b.subsetIndices[name] = j
+	}
+	return j
+}
+
+// Build returns the Instance built. The elements of each subset are sorted and
+// duplicates are removed. All problems found are returned joined together
+// (see errors.Join) and in that case the returned Instance should not be used.
+// The problems found by Validate can be inspected with errors.As.
+func (b *Builder) Build() (Instance, error) {
+	ins := Instance{
+		ElementCount: b.elementCount,
+		Subsets:      make([][]int, 0, len(b.subsets)),
+		Costs:        make([]float64, 0, len(b.costs)),
+	}
+	errs := slices.Clone(b.errs)
+
+	for j, subset := range b.subsets {
+		subset = slices.Clone(subset)
+		slices.Sort(subset)
+		subset = slices.Compact(subset)
+		ins.Subsets = append(ins.Subsets, subset)
+		ins.Costs = append(ins.Costs, b.costs[j])
+	}
+
+	if len(b.elementNames) > 0 {
+		ins.ElementNames = slices.Clone(b.elementNames)
+		for len(ins.ElementNames) < ins.ElementCount {
+			ins.ElementNames = append(ins.ElementNames, "")
+		}
+		for i, name := range ins.ElementNames {
+			if name == "" {
+				errs = append(errs, fmt.Errorf(
+					"element %d has no name but other elements are named", i))
+			}
+		}
+	}
+
+	if b.namedSubsetCount > 0 {
+		ins.SubsetNames = slices.Clone(b.subsetNames)
+		for j, name := range ins.SubsetNames {
+			if name == "" {
+				errs = append(errs, fmt.Errorf(
+					"subset %d has no name but other subsets are named", j))
+			}
+		}
+	}
+
+	// The remaining problems, e.g. empty subsets and non-positive costs, are
+	// found by Validate.
+	if err := Validate(ins); err != nil {
+		errs = append(errs, err)
+	}
+
+	return ins, errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBuilderByIndex(t *testing.T) {
+	b := NewBuilder()
+	b.AddSubset(1.5, 2, 0, 2)
+	b.AddSubset(2.5, 1)
+	ins, err := b.Build()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ins, Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 2}, {1}},
+		Costs:        []float64{1.5, 2.5},
+	})
+}
+
+func TestBuilderByName(t *testing.T) {
+	b := NewBuilder()
+	b.AddNamedSubset("s", 1, "y", "x")
+	b.AddNamedSubset("t", 2, "z", "x", "z")
+	assert.Equal(t, b.Element("y"), 0)
+	ins, err := b.Build()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ins, Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1}, {1, 2}},
+		Costs:        []float64{1, 2},
+		ElementNames: []string{"y", "x", "z"},
+		SubsetNames:  []string{"s", "t"},
+	})
+}
+
+func TestBuilderReportsAllProblems(t *testing.T) {
+	b := NewBuilder()
+	b.AddNamedSubset("s", 0, "x")
+	b.AddNamedSubset("s", 1)
+	b.AddSubset(-1, -3)
+	_, err := b.Build()
+	assert.ErrorContains(t, err, `the subset name "s" is used by both subset 0 and subset 1`)
+	msg := err.Error()
+	assert.Assert(t, strings.Contains(msg, "subset 0 has the cost 0"), msg)
+	assert.Assert(t, strings.Contains(msg, "subset 1 is empty"), msg)
+	assert.Assert(t, strings.Contains(msg, "subset 2 contains element -3 which is not a member of [0, 1)"), msg)
+	assert.Assert(t, strings.Contains(msg, "subset 2 has no name"), msg)
+}
+
+func TestBuilderSetElementCount(t *testing.T) {
+	b := NewBuilder()
+	b.SetElementCount(4)
+	b.AddSubset(1, 0)
+	ins, err := b.Build()
+	assert.NilError(t, err)
+	assert.Equal(t, ins.ElementCount, 4)
+}
@@ -15,41 +15,16 @@
  along with this program.  If not, see <https://www.gnu.org/licenses/>.
 */
 
-// A brute force solver for the "Weighted Exact Cover Problem".
+// A brute force solver for the "Weighted Exact Cover Problem". It is the same
+// as "cover solve -solver brute".
 package main
 
 import (
-	"fmt"
-	"log"
+	"os"
 
-	"github.com/snow-abstraction/cover"
-	"github.com/snow-abstraction/cover/internal/util"
-	"github.com/snow-abstraction/cover/solvers"
+	"github.com/snow-abstraction/cover/internal/cli"
 )
 
 func main() {
-	flags := util.NewFlagSet(`Usage: %s -instance instance.json
-
-%s reads in a problem instance JSON file, solves it and outputs a solution
-to standard out.
-
-Arguments:
-`)
-	filename := flags.String("instance", "", "instance JSON filename")
-	flags.Parse()
-
-	if *filename == "" {
-		log.Fatalln("Please supply the instance file name")
-	}
-
-	ins, err := cover.ReadJsonInstance(*filename)
-	if err != nil {
-		log.Fatalln(err)
-	}
-
-	sol, err := solvers.SolveByBruteForce(*ins)
-	if err != nil {
-		fmt.Printf("failed to optimal solution due to error: %s", err)
-	}
-	fmt.Printf("Solution: %+v\n", sol)
+	cli.Brute(os.Args[0], os.Args[1:])
 }
@@ -0,0 +1,38 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// The cover command for solving and working with "Weighted Exact Cover
+// Problem" instances.
+package main
+
+import (
+	"github.com/snow-abstraction/cover/internal/cli"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+func main() {
+	util.RunSubcommand(`cover solves and works with "Weighted Exact Cover Problem" instances.`,
+		[]util.Subcommand{
+			{Name: "solve", Summary: "solve an instance", Run: cli.Solve},
+			{Name: "generate", Summary: "generate a random instance", Run: cli.Generate},
+			{Name: "convert", Summary: "convert an instance to another format", Run: cli.Convert},
+			{Name: "stats", Summary: "output statistics about an instance", Run: cli.Stats},
+			{Name: "verify", Summary: "verify a solution independently of the solvers", Run: cli.Verify},
+			{Name: "bench", Summary: "time the solving of instances", Run: cli.Bench},
+			{Name: "report", Summary: "write an HTML report of a recorded solve", Run: cli.Report},
+		})
+}
@@ -18,12 +18,8 @@ package main
 
 import (
 	"fmt"
-	"log/slog"
-	"os"
-	"path/filepath"
-	"strings"
 
-	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/cli"
 	"github.com/snow-abstraction/cover/internal/util"
 )
 
@@ -31,63 +27,17 @@ func main() {
 
 	flags := util.NewFlagSet(`Usage: %s -instance instance.json
 
-%s reads in a problem instance JSON or MPS file and outputs it to standard out using
+%s reads in a problem instance JSON, MPS or OR-Library file and outputs it to standard out using
 Go debug formatting.
 
 Arguments:
 `)
-	filename := flags.String("instance", "",
-		"instance filename. The file should end in .json (or .JSON) or .mps (or .MPS). MPS support is experimental.")
+	filename := flags.String("instance", "", cli.InstanceFlagUsage)
 	logLevel := flags.String("logLevel", "Info", "log level (Debug, Info, Warn, Error)")
 	flags.Parse()
 
-	level := parseLogLevel(*logLevel)
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		AddSource: true,
-		Level:     level,
-	})))
-
-	if *filename == "" {
-		fmt.Fprintln(os.Stderr, "Please supply the instance file name")
-		os.Exit(1)
-	}
-
-	ins, err := readInstance(*filename)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to read instance due to error: %s\n", err)
-		os.Exit(1)
-	}
+	cli.SetupLogging(*logLevel)
+	ins := cli.ReadInstance(*filename)
 
 	fmt.Printf("Instance: %#v\n", ins)
 }
-
-func readInstance(filename string) (*cover.Instance, error) {
-	ext := filepath.Ext(filename)
-	lowerExt := strings.ToLower(ext)
-	switch lowerExt {
-	case ".json":
-		return cover.ReadJsonInstance(filename)
-	case ".mps":
-		return cover.ReadMPSInstance(filename)
-	}
-
-	return nil, fmt.Errorf(
-		"the file extension should be .JSON, .json, .MPS or .mps and not %s", ext)
-
-}
-
-func parseLogLevel(level string) slog.Level {
-	switch level {
-	case "Debug":
-		return slog.LevelDebug
-	case "Info":
-		return slog.LevelInfo
-	case "Warn":
-		return slog.LevelWarn
-	case "Error":
-		return slog.LevelError
-	}
-	slog.Error("unknown log level. defaulting to Info")
-
-	return slog.LevelInfo
-}
@@ -15,86 +15,16 @@
  along with this program.  If not, see <https://www.gnu.org/licenses/>.
 */
 
-// A Branch-and-Bound solver for the "Weighted Exact Cover Problem".
+// A Branch-and-Bound solver for the "Weighted Exact Cover Problem". It is the
+// same as "cover solve".
 package main
 
 import (
-	"fmt"
-	"log/slog"
 	"os"
-	"path/filepath"
-	"strings"
 
-	"github.com/snow-abstraction/cover"
-	"github.com/snow-abstraction/cover/internal/solvers"
-	"github.com/snow-abstraction/cover/internal/util"
+	"github.com/snow-abstraction/cover/internal/cli"
 )
 
 func main() {
-	flags := util.NewFlagSet(`Usage: %s -instance instance.json
-
-%s reads in a problem instance JSON or MPS file, solves it and outputs a solution
-to standard out.
-
-Arguments:
-`)
-	filename := flags.String("instance", "",
-		"instance filename. The file should end in .json (or .JSON) or .mps (or .MPS). MPS support is experimental.")
-	logLevel := flags.String("logLevel", "Info", "log level (Debug, Info, Warn, Error)")
-	flags.Parse()
-
-	level := parseLogLevel(*logLevel)
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		AddSource: true,
-		Level:     level,
-	})))
-
-	if *filename == "" {
-		fmt.Fprintln(os.Stderr, "Please supply the instance file name")
-		os.Exit(1)
-	}
-
-	ins, err := readInstance(*filename)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to read instance due to error: %s\n", err)
-		os.Exit(1)
-	}
-
-	sol, err := solvers.SolveByBranchAndBound(*ins)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to optimal solution due to error: %s\n", err)
-		os.Exit(1)
-	}
-	fmt.Printf("Solution: %+v\n", sol)
-}
-
-func readInstance(filename string) (*cover.Instance, error) {
-	ext := filepath.Ext(filename)
-	lowerExt := strings.ToLower(ext)
-	switch lowerExt {
-	case ".json":
-		return cover.ReadJsonInstance(filename)
-	case ".mps":
-		return cover.ReadMPSInstance(filename)
-	}
-
-	return nil, fmt.Errorf(
-		"the file extension should be .JSON, .json, .MPS or .mps and not %s", ext)
-
-}
-
-func parseLogLevel(level string) slog.Level {
-	switch level {
-	case "Debug":
-		return slog.LevelDebug
-	case "Info":
-		return slog.LevelInfo
-	case "Warn":
-		return slog.LevelWarn
-	case "Error":
-		return slog.LevelError
-	}
-	slog.Error("unknown log level. defaulting to Info")
-
-	return slog.LevelInfo
+	cli.Solve(os.Args[0], os.Args[1:])
 }
@@ -0,0 +1,30 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// An independent verifier of solutions to the "Weighted Exact Cover Problem".
+// It is the same as "cover verify".
+package main
+
+import (
+	"os"
+
+	"github.com/snow-abstraction/cover/internal/cli"
+)
+
+func main() {
+	cli.Verify(os.Args[0], os.Args[1:])
+}
@@ -0,0 +1,160 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import "slices"
+
+// Component is a connected component of the element-subset incidence graph
+// of an instance. That is, no subset contains elements from two different
+// components. So exact covers of the components can be found independently
+// and combined to an exact cover of the instance.
+type Component struct {
+	// Sorted indices of the elements in the component.
+	Elements []int
+	// Sorted indices of the subsets in the component.
+	Subsets []int
+}
+
+// Components finds the connected components of the instance's element-subset
+// incidence graph. The components are ordered by their smallest element index.
+// An element not in any subset is a component without subsets. The instance
+// is assumed to be valid, see Validate.
+func Components(ins Instance) []Component {
+	// union-find over the elements
+	parent := make([]int, ins.ElementCount)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]] // path halving
+			i = parent[i]
+		}
+		return i
+	}
+
+	for _, subset := range ins.Subsets {
+		first := find(subset[0])
+		for _, e := range subset[1:] {
+			if root := find(e); root != first {
+				// Link to the smaller root so the root of each component is
+				// its smallest element.
+				if root < first {
+					parent[first] = root
+					first = root
+				} else {
+					parent[root] = first
+				}
+			}
+		}
+	}
+
+	componentIndex := make([]int, ins.ElementCount)
+	components := make([]Component, 0)
+	for i := 0; i < ins.ElementCount; i++ {
+		root := find(i)
+		if root == i {
+			componentIndex[i] = len(components)
+			components = append(components, Component{Elements: []int{}, Subsets: []int{}})
+		} else {
+			componentIndex[i] = componentIndex[root]
+		}
+		c := &components[componentIndex[i]]
+		c.Elements = append(c.Elements, i)
+	}
+
+	for j, subset := range ins.Subsets {
+		c := &components[componentIndex[subset[0]]]
+		c.Subsets = append(c.Subsets, j)
+	}
+
+	return components
+}
+
+// SubInstance makes an instance of only the elements and subsets in the
+// component. The elements are renumbered 0, ..., len(c.Elements)-1 in the
+// order of c.Elements and subset j of the new instance is subset c.Subsets[j].
+func (ins Instance) SubInstance(c Component) Instance {
+	newIndex := make(map[int]int, len(c.Elements))
+	for newIdx, i := range c.Elements {
+		newIndex[i] = newIdx
+	}
+
+	sub := Instance{
+		ElementCount: len(c.Elements),
+		Subsets:      make([][]int, 0, len(c.Subsets)),
+		Costs:        make([]float64, 0, len(c.Subsets)),
+	}
+	for _, j := range c.Subsets {
+		subset := make([]int, 0, len(ins.Subsets[j]))
+		for _, i := range ins.Subsets[j] {
+			subset = append(subset, newIndex[i])
+		}
+		sub.Subsets = append(sub.Subsets, subset)
+		sub.Costs = append(sub.Costs, ins.Costs[j])
+	}
+
+	if ins.ElementNames != nil {
+		sub.ElementNames = make([]string, 0, len(c.Elements))
+		for _, i := range c.Elements {
+			sub.ElementNames = append(sub.ElementNames, ins.ElementNames[i])
+		}
+	}
+	if ins.SubsetNames != nil {
+		sub.SubsetNames = make([]string, 0, len(c.Subsets))
+		for _, j := range c.Subsets {
+			sub.SubsetNames = append(sub.SubsetNames, ins.SubsetNames[j])
+		}
+	}
+
+	return sub
+}
+
+// RenumberElements returns a copy of the instance where the elements are
+// renumbered in the order of their first appearance in the subsets. Elements
+// in no subset come last in their original order. The element names, if any,
+// are kept with their elements and the elements of each subset are sorted.
+// The returned originalElements maps the new element indices to the
+// original, i.e. new element i is element originalElements[i] of ins.
+func RenumberElements(ins Instance) (renumbered Instance, originalElements []int) {
+	seen := make([]bool, ins.ElementCount)
+	originalElements = make([]int, 0, ins.ElementCount)
+	for _, subset := range ins.Subsets {
+		for _, i := range subset {
+			if !seen[i] {
+				seen[i] = true
+				originalElements = append(originalElements, i)
+			}
+		}
+	}
+	for i, s := range seen {
+		if !s {
+			originalElements = append(originalElements, i)
+		}
+	}
+
+	all := make([]int, len(ins.Subsets))
+	for j := range all {
+		all[j] = j
+	}
+	renumbered = ins.SubInstance(Component{originalElements, all})
+	for _, subset := range renumbered.Subsets {
+		slices.Sort(subset)
+	}
+	return renumbered, originalElements
+}
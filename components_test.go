@@ -0,0 +1,68 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestComponents(t *testing.T) {
+	ins := Instance{
+		ElementCount: 6,
+		Subsets:      [][]int{{3, 5}, {0}, {1, 4}, {0, 4}, {5}},
+		Costs:        []float64{1, 2, 3, 4, 5},
+		ElementNames: []string{"a", "b", "c", "d", "e", "f"},
+	}
+	components := Components(ins)
+	assert.DeepEqual(t, components, []Component{
+		{Elements: []int{0, 1, 4}, Subsets: []int{1, 2, 3}},
+		{Elements: []int{2}, Subsets: []int{}},
+		{Elements: []int{3, 5}, Subsets: []int{0, 4}},
+	})
+
+	assert.DeepEqual(t, ins.SubInstance(components[0]), Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0}, {1, 2}, {0, 2}},
+		Costs:        []float64{2, 3, 4},
+		ElementNames: []string{"a", "b", "e"},
+	})
+}
+
+func TestComponentsOfEmptyInstance(t *testing.T) {
+	assert.DeepEqual(t, Components(Instance{}), []Component{})
+}
+
+func TestRenumberElements(t *testing.T) {
+	ins := Instance{
+		ElementCount: 5,
+		Subsets:      [][]int{{2, 4}, {0, 4}, {1}},
+		Costs:        []float64{1, 2, 3},
+		ElementNames: []string{"a", "b", "c", "d", "e"},
+	}
+	renumbered, originalElements := RenumberElements(ins)
+	assert.DeepEqual(t, originalElements, []int{2, 4, 0, 1, 3})
+	assert.DeepEqual(t, renumbered, Instance{
+		ElementCount: 5,
+		Subsets:      [][]int{{0, 1}, {1, 2}, {3}},
+		Costs:        []float64{1, 2, 3},
+		ElementNames: []string{"c", "e", "a", "b", "d"},
+	})
+	assert.NilError(t, Validate(renumbered))
+}
@@ -18,12 +18,11 @@
 package cover
 
 import (
-	"encoding/json"
 	"math"
 	"math/rand"
-	"os"
 	"slices"
 	"sort"
+	"strconv"
 )
 
 type Instance struct {
@@ -39,6 +38,45 @@ type Instance struct {
 	// The restrictions on the Costs reasonable for many problems and
 	// suit certain algorithms.
 	Costs []float64
+	// Optional names of the elements. If not nil, ElementNames[i] is the
+	// name of element i and the length must be ElementCount.
+	ElementNames []string `json:",omitempty"`
+	// Optional names of the subsets. If not nil, SubsetNames[j] is the name
+	// of subset j and the length must be equal to the length of Subsets.
+	SubsetNames []string `json:",omitempty"`
+}
+
+// ElementName returns the name of element i. If the instance has no element
+// names, the name is "r" followed by the index, e.g. "r3", as it is row i in
+// the ILP formulations.
+func (ins Instance) ElementName(i int) string {
+	if ins.ElementNames == nil {
+		return "r" + strconv.Itoa(i)
+	}
+	return ins.ElementNames[i]
+}
+
+// SubsetName returns the name of subset j. If the instance has no subset
+// names, the name is "c" followed by the index, e.g. "c3", as it is column j
+// in the ILP formulations.
+func (ins Instance) SubsetName(j int) string {
+	if ins.SubsetNames == nil {
+		return "c" + strconv.Itoa(j)
+	}
+	return ins.SubsetNames[j]
+}
+
+// SubsetNamesOf returns the names of the subsets with the indices. If the
+// instance has no subset names, nil is returned.
+func (ins Instance) SubsetNamesOf(indices []int) []string {
+	if ins.SubsetNames == nil {
+		return nil
+	}
+	names := make([]string, 0, len(indices))
+	for _, idx := range indices {
+		names = append(names, ins.SubsetNames[idx])
+	}
+	return names
 }
 
 // MakeRandomInstance makes a random Instance with m elements and n subsets
@@ -98,17 +136,10 @@ func MakeRandomInstance(m int, n int, costScale float64, seed int64) Instance {
 	return ins
 }
 
+// ReadJsonInstance reads an instance from a JSON file, which may be
+// compressed. See ReadJSON.
 func ReadJsonInstance(filename string) (*Instance, error) {
-	b, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-
-	var ins Instance
-	if err := json.Unmarshal(b, &ins); err != nil {
-		return nil, err
-	}
-	return &ins, nil
+	return readInstanceFile(filename, ReadJSON)
 }
 
 // Subsets with an evaluation of them w.r.t. some instance.
@@ -122,6 +153,9 @@ type SubsetsEval struct {
 	// If the SubsetsIndices constitute a proven optimum. This can only be true if
 	// ExactlyCovered is true.
 	Optimal bool
+	// The names of the subsets in SubsetsIndices if the instance has subset
+	// names. Otherwise nil.
+	SubsetNames []string `json:",omitempty"`
 }
 
 // For specifying data related to test instances
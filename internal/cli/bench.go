@@ -0,0 +1,208 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/solvers"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+// benchResult is the result of solving one instance repeatedly.
+type benchResult struct {
+	Instance string
+	Status   cover.SolutionStatus
+	Cost     float64
+	// The fastest and median times over the repetitions.
+	BestSeconds   float64
+	MedianSeconds float64
+	// If the result agrees with the reference solution of a test suite
+	// instance. nil if there is no reference solution.
+	MatchesReference *bool `json:",omitempty"`
+	// Branch-and-bound statistics of the last repetition. They are zero for
+	// the brute force solver.
+	Nodes                     int      `json:",omitempty"`
+	MaxOpenNodes              int      `json:",omitempty"`
+	MeanSubgradientIterations float64  `json:",omitempty"`
+	DualSeconds               float64  `json:",omitempty"`
+	RootBound                 *float64 `json:",omitempty"`
+}
+
+type benchInstance struct {
+	path          string
+	referencePath string
+}
+
+// Bench is the bench subcommand.
+func Bench(name string, args []string) {
+	flags := util.NewCommandFlagSet(name, `Usage: %s -suite tiny
+       %s -repeat 5 instance1.json instance2.mps
+
+Solves each instance repeatedly and reports the times. The instances are
+either the instance files given as arguments or the instances of a test
+suite, see generate_test_instances_and_solutions. The test suite solutions
+found by tools/solve_sc.py are used to check the results.
+
+Arguments:
+`)
+	solverName := flags.String("solver", "bb", "solver (bb for branch-and-bound, brute for brute force or dlx for dancing links)")
+	repeat := flags.Int("repeat", 1, "number of times to solve each instance")
+	suite := flags.String("suite", "", "test suite name, e.g. tiny or small")
+	testdata := flags.String("testdata", "testdata", "directory of the test suites")
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	solve := solverWithStats(*solverName, solvers.Options{})
+	if *repeat < 1 {
+		Fatalf("repeat must be at least 1")
+	}
+
+	var instances []benchInstance
+	if *suite != "" {
+		var err error
+		instances, err = loadSuite(*testdata, *suite)
+		if err != nil {
+			Fatalf("failed to load the test suite due to error: %s", err)
+		}
+	}
+	for _, path := range flags.Args() {
+		instances = append(instances, benchInstance{path: path})
+	}
+	if len(instances) == 0 {
+		Fatalf("Please supply a test suite or instance files")
+	}
+
+	results := make([]benchResult, 0, len(instances))
+	for _, bi := range instances {
+		ins := ReadInstance(bi.path)
+		result := benchResult{Instance: bi.path}
+		times := make([]float64, 0, *repeat)
+		var run solveRun
+		for k := 0; k < *repeat; k++ {
+			start := time.Now()
+			var err error
+			run, err = solve(*ins)
+			if err != nil {
+				Fatalf("failed to solve %s due to error: %s", bi.path, err)
+			}
+			times = append(times, time.Since(start).Seconds())
+		}
+		slices.Sort(times)
+		result.BestSeconds = times[0]
+		result.MedianSeconds = times[len(times)/2]
+		s := cover.NewSolution(*ins, run.eval)
+		result.Status = s.Status
+		result.Cost = s.Cost
+		if *solverName == "bb" {
+			result.Nodes = run.stats.Nodes
+			result.MaxOpenNodes = run.stats.MaxOpenNodes
+			result.MeanSubgradientIterations = run.stats.MeanSubgradientIterations()
+			result.DualSeconds = run.stats.DualTime.Seconds()
+			if !math.IsInf(run.stats.RootBound, 0) {
+				result.RootBound = &run.stats.RootBound
+			}
+		}
+
+		if bi.referencePath != "" {
+			matches, err := matchesReference(s, bi.referencePath)
+			if err != nil {
+				Fatalf("failed to read reference solution due to error: %s", err)
+			}
+			result.MatchesReference = &matches
+		}
+		results = append(results, result)
+	}
+
+	if common.JSON() {
+		if err := WriteJSON(os.Stdout, results); err != nil {
+			Fatalf("failed to write results due to error: %s", err)
+		}
+	} else {
+		printBenchResults(results)
+	}
+	for _, result := range results {
+		if result.MatchesReference != nil && !*result.MatchesReference {
+			os.Exit(1)
+		}
+	}
+}
+
+func loadSuite(testdata string, suite string) ([]benchInstance, error) {
+	b, err := os.ReadFile(filepath.Join(testdata, suite+"_instance_specifications.json"))
+	if err != nil {
+		return nil, err
+	}
+	var specifications []cover.TestInstanceSpecification
+	if err := json.Unmarshal(b, &specifications); err != nil {
+		return nil, err
+	}
+
+	// The paths in the specifications are relative to the parent of testdata.
+	root := filepath.Dir(filepath.Clean(testdata))
+	instances := make([]benchInstance, 0, len(specifications))
+	for _, spec := range specifications {
+		instances = append(instances, benchInstance{
+			path:          filepath.Join(root, spec.InstancePath),
+			referencePath: filepath.Join(root, spec.PythonSolutionPath),
+		})
+	}
+	return instances, nil
+}
+
+func matchesReference(sol cover.Solution, referencePath string) (bool, error) {
+	reference, err := ReadSolutionFile(referencePath)
+	if err != nil {
+		return false, err
+	}
+	if sol.Status != reference.Status {
+		return false, nil
+	}
+	return cover.CostsEqual(sol.Cost, reference.Cost), nil
+}
+
+func printBenchResults(results []benchResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "instance\tstatus\tcost\tbest (s)\tmedian (s)\tnodes\titerations/node\troot bound\treference")
+	for _, r := range results {
+		reference := "-"
+		if r.MatchesReference != nil {
+			reference = "mismatch"
+			if *r.MatchesReference {
+				reference = "ok"
+			}
+		}
+		rootBound := "-"
+		if r.RootBound != nil {
+			rootBound = fmt.Sprintf("%.6g", *r.RootBound)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.6g\t%.6f\t%.6f\t%d\t%.1f\t%s\t%s\n",
+			r.Instance, r.Status, r.Cost, r.BestSeconds, r.MedianSeconds,
+			r.Nodes, r.MeanSubgradientIterations, rootBound, reference)
+	}
+	w.Flush()
+}
@@ -0,0 +1,149 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package cli has the subcommands of the cover command and the helpers
+// shared by the commands, e.g. reading instances and setting up logging.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+// InstanceFlagUsage is the usage of the flags for instance filenames.
+const InstanceFlagUsage = "instance filename. The format is determined by the extension: .json, .mps, .cover\n" +
+	"(binary), .scp (OR-Library set covering) or .spp (OR-Library set partitioning), ignoring\n" +
+	"case. OR-Library .txt files whose names start with scp or spp, e.g. scp41.txt, are also\n" +
+	"supported. Files may be gzip or bzip2 compressed, e.g. instance.mps.gz."
+
+// Fatalf prints the message to standard error and exits with status 1.
+func Fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
+
+// CommonFlags are the flags shared by the commands.
+type CommonFlags struct {
+	logLevel *string
+	output   *string
+}
+
+// AddCommonFlags adds the -logLevel and -output flags.
+func AddCommonFlags(fs *util.FlagSet) *CommonFlags {
+	return &CommonFlags{
+		logLevel: fs.String("logLevel", "Info", "log level (Debug, Info, Warn, Error)"),
+		output:   fs.String("output", "text", "output mode (text or json)"),
+	}
+}
+
+// Setup sets up the logging and checks the flags. It must be called after
+// the flags have been parsed.
+func (c *CommonFlags) Setup() {
+	SetupLogging(*c.logLevel)
+	if *c.output != "text" && *c.output != "json" {
+		fmt.Fprintf(os.Stderr, "the output mode must be text or json and not %s\n", *c.output)
+		os.Exit(2)
+	}
+}
+
+// JSON reports if the output should be JSON instead of text for humans.
+func (c *CommonFlags) JSON() bool {
+	return *c.output == "json"
+}
+
+// SetupLogging sets the default logger to log text to standard error.
+func SetupLogging(level string) {
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     ParseLogLevel(level),
+	})))
+}
+
+// ParseLogLevel parses one of Debug, Info, Warn and Error.
+func ParseLogLevel(level string) slog.Level {
+	switch level {
+	case "Debug":
+		return slog.LevelDebug
+	case "Info":
+		return slog.LevelInfo
+	case "Warn":
+		return slog.LevelWarn
+	case "Error":
+		return slog.LevelError
+	}
+	slog.Error("unknown log level. defaulting to Info")
+
+	return slog.LevelInfo
+}
+
+// ReadInstance reads the instance using cover.ReadInstance and validates it
+// using cover.Validate or exits if it can not be read or is invalid.
+func ReadInstance(filename string) *cover.Instance {
+	if filename == "" {
+		Fatalf("Please supply the instance file name")
+	}
+	ins, err := cover.ReadInstance(filename)
+	if err != nil {
+		Fatalf("failed to read instance due to error: %s", err)
+	}
+	// Not all formats are validated when read, e.g. JSON, and the commands
+	// assume valid instances.
+	if err := cover.Validate(*ins); err != nil {
+		Fatalf("invalid instance: %s", err)
+	}
+	return ins
+}
+
+// WriteSolutionFile writes the solution to the file in the solution JSON
+// format.
+func WriteSolutionFile(filename string, sol cover.Solution) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := cover.WriteSolution(file, sol); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// ReadSolutionFile reads a solution file, see cover.ReadSolution.
+func ReadSolutionFile(filename string) (*cover.Solution, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return cover.ReadSolution(file)
+}
+
+// WriteJSON writes v as indented JSON, which is the JSON output mode.
+func WriteJSON(w io.Writer, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
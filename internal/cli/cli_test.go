@@ -0,0 +1,133 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/solvers"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrintAnalysis(t *testing.T) {
+	ins := cover.Instance{
+		ElementCount: 4,
+		Subsets:      [][]int{{0, 1}, {1}, {2}},
+		Costs:        []float64{2, 1, 3},
+	}
+	var b strings.Builder
+	printAnalysis(&b, ins, cover.Analyze(ins))
+	assert.Assert(t, strings.Contains(b.String(), " 1:2 2:1\n"), b.String())
+	assert.Assert(t, strings.Contains(b.String(), " 2 (r0, r2)\n"), b.String())
+	assert.Assert(t, strings.Contains(b.String(), "The instance is infeasible"), b.String())
+}
+
+func TestParseIndices(t *testing.T) {
+	indices, err := parseIndices("3, 1,4")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, indices, []int{3, 1, 4})
+	_, err = parseIndices("3,,4")
+	assert.ErrorContains(t, err, "unable to parse '' as a subset index")
+}
+
+func TestLoadSuite(t *testing.T) {
+	instances, err := loadSuite("../../testdata", "tiny")
+	assert.NilError(t, err)
+	assert.Assert(t, len(instances) > 0)
+	for _, bi := range instances {
+		ins, err := cover.ReadInstance(bi.path)
+		assert.NilError(t, err)
+		sol, err := solverByName("bb")(*ins)
+		assert.NilError(t, err)
+		matches, err := matchesReference(cover.NewSolution(*ins, sol), bi.referencePath)
+		assert.NilError(t, err)
+		assert.Assert(t, matches, bi.path)
+	}
+}
+
+func TestMakeSolutionWithLimit(t *testing.T) {
+	ins := cover.Instance{ElementCount: 1, Subsets: [][]int{{0}}, Costs: []float64{4}}
+
+	s := makeSolution(ins, cover.SubsetsEval{}, solvers.Stats{LowerBound: 1, LimitReached: true})
+	assert.Equal(t, s.Status, cover.StatusUnknown)
+	assert.Equal(t, s.Bound, 1.0)
+	assert.Equal(t, exitStatus(s.Status), ExitUnknown)
+
+	s = makeSolution(ins, cover.SubsetsEval{SubsetsIndices: []int{0}, ExactlyCovered: true, Cost: 4},
+		solvers.Stats{LowerBound: 3, LimitReached: true})
+	assert.Equal(t, s.Status, cover.StatusFeasible)
+	assert.Equal(t, s.Gap, 0.25)
+	assert.Equal(t, exitStatus(s.Status), ExitFeasible)
+
+	s = makeSolution(ins, cover.SubsetsEval{}, solvers.Stats{LowerBound: math.Inf(1)})
+	assert.Equal(t, s.Status, cover.StatusInfeasible)
+	assert.Equal(t, exitStatus(s.Status), ExitInfeasible)
+}
+
+func TestCombinedProgress(t *testing.T) {
+	var logged []solvers.Progress
+	c := newCombinedProgress(2, 0, func(p solvers.Progress) { logged = append(logged, p) })
+	k0, onProgress0 := c.component()
+	k1, onProgress1 := c.component()
+	assert.Equal(t, k0, 0)
+	assert.Equal(t, k1, 1)
+
+	// An incumbent of one component is not an exact cover of the instance.
+	onProgress0(solvers.Progress{Nodes: 3, OpenNodes: 2, Bound: 1, Incumbent: 2, NewIncumbent: true})
+	assert.Equal(t, len(logged), 0)
+
+	onProgress1(solvers.Progress{Nodes: 4, OpenNodes: 1, Bound: 2, Incumbent: 3, NewIncumbent: true})
+	assert.Equal(t, len(logged), 1)
+	p := logged[0]
+	assert.Equal(t, p.Nodes, 7)
+	assert.Equal(t, p.OpenNodes, 3)
+	assert.Equal(t, p.Bound, 3.0)
+	assert.Equal(t, p.Incumbent, 5.0)
+	assert.Equal(t, p.Gap, 0.4)
+	assert.Assert(t, p.NewIncumbent)
+
+	c.finish(k0, solveRun{cover.SubsetsEval{ExactlyCovered: true, Cost: 2}, solvers.Stats{Nodes: 5, LowerBound: 2}})
+	onProgress1(solvers.Progress{Nodes: 6, Bound: 2.5, Incumbent: 2.5, NewIncumbent: true})
+	assert.Equal(t, len(logged), 2)
+	p = logged[1]
+	assert.Equal(t, p.Nodes, 11)
+	assert.Equal(t, p.OpenNodes, 0)
+	assert.Equal(t, p.Incumbent, 4.5)
+	assert.Equal(t, p.Gap, 0.0)
+}
+
+func TestWriteReport(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	recorder := solvers.NewRecorder()
+	eval, _, err := solvers.SolveByBranchAndBoundWithOptions(ins, solvers.Options{Recorder: recorder})
+	assert.NilError(t, err)
+	sol := cover.NewSolution(ins, eval)
+
+	var b strings.Builder
+	assert.NilError(t, writeReport(&b, recorder.Records(), &ins, &sol))
+	report := b.String()
+	assert.Assert(t, strings.Contains(report, `<polyline fill="none" stroke="#2a7"`), "no incumbent line")
+	assert.Assert(t, strings.Contains(report, "#0 root"))
+	assert.Equal(t, strings.Count(report, "<tr><td>"), len(sol.Solution))
+	// self-contained
+	assert.Assert(t, !strings.Contains(report, "src="))
+	assert.Assert(t, !strings.Contains(report, "href="))
+}
@@ -0,0 +1,218 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/util"
+	"github.com/snow-abstraction/cover/presolve"
+)
+
+var formatNames = map[string]cover.Format{
+	"json":   cover.FormatJSON,
+	"mps":    cover.FormatMPS,
+	"lp":     cover.FormatLP,
+	"scp":    cover.FormatORLibSCP,
+	"spp":    cover.FormatORLibSPP,
+	"binary": cover.FormatBinary,
+}
+
+const formatFlagUsage = "output format (json, mps, lp, scp, spp or binary). If empty, the format is determined by the\n" +
+	"output filename's extension."
+
+// outputFormat returns the format named by the -format flag or otherwise
+// implied by the output filename. If neither is known, defaultFormat is
+// returned.
+func outputFormat(formatName string, filename string, defaultFormat cover.Format) cover.Format {
+	if formatName != "" {
+		format, found := formatNames[strings.ToLower(formatName)]
+		if !found {
+			fmt.Fprintf(os.Stderr, "unknown format %s\n", formatName)
+			os.Exit(2)
+		}
+		return format
+	}
+	if format := cover.FormatFromFilename(filename); format != cover.FormatUnknown {
+		return format
+	}
+	if defaultFormat == cover.FormatUnknown {
+		fmt.Fprintln(os.Stderr, "Please supply the output format or an output file name with a known extension")
+		os.Exit(2)
+	}
+	return defaultFormat
+}
+
+// WriteInstanceFile writes the instance in the format to the file or to
+// standard out if filename is empty. If the filename ends in .gz, the output
+// is gzip compressed.
+func WriteInstanceFile(filename string, ins cover.Instance, format cover.Format) error {
+	if filename == "" {
+		w := bufio.NewWriter(os.Stdout)
+		if err := cover.WriteFormat(w, ins, format); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+	if strings.HasSuffix(strings.ToLower(filename), ".bz2") {
+		return fmt.Errorf("bzip2 compression is only supported for reading")
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var w io.Writer = file
+	var gw *gzip.Writer
+	if strings.HasSuffix(strings.ToLower(filename), ".gz") {
+		gw = gzip.NewWriter(file)
+		w = gw
+	}
+	if err := cover.WriteFormat(w, ins, format); err != nil {
+		return err
+	}
+	if gw != nil {
+		if err := gw.Close(); err != nil {
+			return err
+		}
+	}
+	return file.Close()
+}
+
+// forcedSubsets are the subsets that presolve found to be in every exact
+// cover. They are written separately by convert since they are not in the
+// presolved instance.
+type forcedSubsets struct {
+	// The indices of the subsets in the input instance.
+	Subsets []int
+	// The names of the subsets if the input instance has subset names.
+	SubsetNames []string
+	// The sum of the subsets' costs. Adding it to the optimal cost of the
+	// presolved instance gives the optimal cost of the input instance.
+	Cost float64
+}
+
+func writeForcedSubsets(filename string, forced forcedSubsets) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := WriteJSON(file, forced); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Convert is the convert subcommand.
+func Convert(name string, args []string) {
+	flags := util.NewCommandFlagSet(name, `Usage: %s -in instance.mps -out instance.json
+
+%s reads in a problem instance file and writes it in another format. The
+formats are JSON (.json), MPS (.mps), LP (.lp, write only), OR-Library
+(.scp and .spp) and the compact binary format (.cover). If the output
+filename ends in .gz, the output is gzip compressed.
+
+The optional transformations are applied in the order: -dedupe, -presolve,
+-component and -renumber.
+
+Arguments:
+`)
+	in := flags.String("in", "", InstanceFlagUsage)
+	out := flags.String("out", "", "output filename. If empty, the output is written to standard out.")
+	formatName := flags.String("format", "", formatFlagUsage)
+	dedupe := flags.Bool("dedupe", false, "remove the more expensive subsets of subsets with the same elements")
+	usePresolve := flags.Bool("presolve", false,
+		"reduce the instance using presolve. The forced subsets are not in the reduced instance, so this\n"+
+			"requires -forced.")
+	forcedFile := flags.String("forced", "",
+		"filename for the subsets forced by -presolve, their names and their cost as JSON. Their indices\n"+
+			"are those of the input instance.")
+	component := flags.Int("component", -1,
+		"if not negative, extract this connected component. The components are numbered from 0\n"+
+			"ordered by their smallest element.")
+	renumber := flags.Bool("renumber", false, "renumber the elements in the order of their first appearance in the subsets")
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	if *usePresolve && *forcedFile == "" {
+		Fatalf("-presolve requires -forced since the written instance has a different optimal cost without the " +
+			"forced subsets")
+	}
+	format := outputFormat(*formatName, *out, cover.FormatUnknown)
+	ins := ReadInstance(*in)
+	input := ins
+
+	// originalIndices maps the subset indices of ins to those of the input.
+	var originalIndices []int
+	if *dedupe {
+		var reduced cover.Instance
+		reduced, originalIndices = cover.RemoveMoreExpensiveDuplicates(*ins)
+		slog.Info("removed duplicates", "subsets", len(ins.Subsets)-len(reduced.Subsets))
+		ins = &reduced
+	}
+	if *usePresolve {
+		r, err := presolve.Presolve(*ins)
+		if err != nil {
+			Fatalf("failed to presolve instance due to error: %s", err)
+		}
+		if r.Report.Infeasible {
+			Fatalf("presolve found the instance infeasible")
+		}
+		slog.Info("presolved", "report", fmt.Sprintf("%+v", r.Report),
+			"forced subsets", r.Forced, "forced cost", r.ForcedCost)
+		forced := forcedSubsets{Subsets: make([]int, 0, len(r.Forced)), Cost: r.ForcedCost}
+		for _, j := range r.Forced {
+			if originalIndices != nil {
+				j = originalIndices[j]
+			}
+			forced.Subsets = append(forced.Subsets, j)
+		}
+		forced.SubsetNames = input.SubsetNamesOf(forced.Subsets)
+		if err := writeForcedSubsets(*forcedFile, forced); err != nil {
+			Fatalf("failed to write the forced subsets due to error: %s", err)
+		}
+		ins = &r.Instance
+	}
+	if *component >= 0 {
+		components := cover.Components(*ins)
+		if *component >= len(components) {
+			Fatalf("the instance only has %d components", len(components))
+		}
+		sub := ins.SubInstance(components[*component])
+		ins = &sub
+	}
+	if *renumber {
+		renumbered, _ := cover.RenumberElements(*ins)
+		ins = &renumbered
+	}
+
+	if err := WriteInstanceFile(*out, *ins, format); err != nil {
+		Fatalf("failed to write instance due to error: %s", err)
+	}
+}
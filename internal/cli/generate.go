@@ -0,0 +1,68 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+// Generate is the generate subcommand.
+func Generate(name string, args []string) {
+	flags := util.NewCommandFlagSet(name, `Usage: %s -seed 1 -m 10 -n 100 -costScale 1.0
+
+%s outputs a random instance. The instance generated may be infeasible.
+
+For certain m and n will take a long time because each subset is generated
+randomly but must be unique. If the number of possible nonempty subsets
+(2^m-1) is less than n, an error is reported.
+
+Arguments:
+`)
+	m := flags.Int("m", 0, "number of elements to be covered")
+	n := flags.Int("n", 0, "number of subsets")
+	scale := flags.Float64("costScale", 1.0, "scale factor for subset costs")
+	seed := flags.Int64("seed", 1, "seed for the random generator")
+	out := flags.String("out", "", "output filename. If empty, the output is written to standard out.")
+	formatName := flags.String("format", "", formatFlagUsage+" The default is json.")
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	if *m < 0 {
+		Fatalf("m must be non-negative (0 <= m)")
+	}
+	if *n < 0 {
+		Fatalf("n must be non-negative (0 <= n)")
+	}
+	if *m < 63 && int64(*n) > (int64(1)<<*m)-1 {
+		Fatalf("there are only %d nonempty subsets of %d elements but n is %d", (int64(1)<<*m)-1, *m, *n)
+	}
+	if *scale <= 0.0 {
+		Fatalf("costScale must be strictly positive (0.0 < costScale)")
+	}
+
+	format := outputFormat(*formatName, *out, cover.FormatJSON)
+	ins := cover.Instance{Subsets: make([][]int, 0), Costs: make([]float64, 0)}
+	if *n > 0 {
+		ins = cover.MakeRandomInstance(*m, *n, *scale, *seed)
+	}
+	if err := WriteInstanceFile(*out, ins, format); err != nil {
+		Fatalf("failed to write instance due to error: %s", err)
+	}
+}
@@ -0,0 +1,295 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/solvers"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+//go:embed report.html
+var reportHTML string
+
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+// reportNode is a node of the branch-and-bound tree in the report.
+type reportNode struct {
+	solvers.NodeRecord
+	Children []*reportNode
+	// The subgradient convergence chart.
+	Convergence template.HTML
+	// If the node is expanded initially.
+	Open bool
+}
+
+func (n *reportNode) Label() string {
+	label := n.Kind
+	if n.Kind != "root" {
+		label = fmt.Sprintf("%s (%d, %d)", n.Kind, n.I, n.J)
+	}
+	if n.DualBound != nil {
+		label += fmt.Sprintf(" dual %.6g", *n.DualBound)
+	}
+	return label + " " + n.Outcome
+}
+
+// reportSubset is a subset of the final cover in the report.
+type reportSubset struct {
+	Index    int
+	Name     string
+	Cost     float64
+	Elements string
+}
+
+// reportData is the data of the report template.
+type reportData struct {
+	Nodes         int
+	Outcomes      map[string]int
+	Roots         []*reportNode
+	Chart         template.HTML
+	Solution      *cover.Solution
+	Cover         []reportSubset
+	Problems      []string
+	LastTime      float64
+	LastIncumbent *float64
+	LastBound     *float64
+}
+
+// Report is the report subcommand.
+func Report(name string, args []string) {
+	flags := util.NewCommandFlagSet(name, `Usage: %s -record record.jsonl -out report.html
+
+%s writes a self-contained HTML report of a solve recorded by solve -record.
+It shows the branch-and-bound tree, the incumbent and bound over time, the
+subgradient convergence of each node and, if the instance and solution are
+supplied, the final cover.
+
+Arguments:
+`)
+	recordFile := flags.String("record", "", "node records filename, as written by solve -record")
+	filename := flags.String("instance", "", "optional instance filename, used to show the final cover")
+	solutionFile := flags.String("solution", "", "optional solution filename, as written by solve -solution")
+	out := flags.String("out", "", "output filename. If empty, the report is written to standard out.")
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	if *recordFile == "" {
+		Fatalf("Please supply the node records file name")
+	}
+	f, err := os.Open(*recordFile)
+	if err != nil {
+		Fatalf("failed to read node records due to error: %s", err)
+	}
+	records, err := solvers.ReadJSONLines(f)
+	f.Close()
+	if err != nil {
+		Fatalf("failed to read node records due to error: %s", err)
+	}
+
+	var ins *cover.Instance
+	if *filename != "" {
+		ins = ReadInstance(*filename)
+	}
+	var sol *cover.Solution
+	if *solutionFile != "" {
+		sol, err = ReadSolutionFile(*solutionFile)
+		if err != nil {
+			Fatalf("failed to read solution due to error: %s", err)
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			Fatalf("failed to write report due to error: %s", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := writeReport(w, records, ins, sol); err != nil {
+		Fatalf("failed to write report due to error: %s", err)
+	}
+}
+
+// writeReport writes the HTML report. ins and sol may be nil.
+func writeReport(w io.Writer, records []solvers.NodeRecord, ins *cover.Instance, sol *cover.Solution) error {
+	data := reportData{
+		Nodes:    len(records),
+		Outcomes: make(map[string]int),
+		Solution: sol,
+	}
+
+	nodes := make([]*reportNode, len(records))
+	for i, rec := range records {
+		if rec.ID != i || rec.Parent >= i {
+			return fmt.Errorf("node record %d: the records are not in recorded order", i)
+		}
+		node := &reportNode{NodeRecord: rec, Open: rec.Depth < 2}
+		node.Convergence = sparkline(rec.Convergence)
+		nodes[i] = node
+		if rec.Parent < 0 {
+			data.Roots = append(data.Roots, node)
+		} else {
+			parent := nodes[rec.Parent]
+			parent.Children = append(parent.Children, node)
+		}
+		data.Outcomes[rec.Outcome]++
+	}
+
+	if len(records) > 0 {
+		last := records[len(records)-1]
+		data.LastTime, data.LastIncumbent, data.LastBound = last.Time, last.Incumbent, last.Bound
+	}
+	// The incumbents and bounds of different components are not comparable.
+	if len(data.Roots) == 1 {
+		data.Chart = progressChart(records)
+	}
+
+	if sol != nil && ins != nil {
+		for _, j := range sol.Solution {
+			if j < 0 || j >= len(ins.Subsets) {
+				continue
+			}
+			elements := make([]string, 0, len(ins.Subsets[j]))
+			for _, i := range ins.Subsets[j] {
+				elements = append(elements, ins.ElementName(i))
+			}
+			data.Cover = append(data.Cover, reportSubset{j, ins.SubsetName(j), ins.Costs[j], strings.Join(elements, ", ")})
+		}
+		if err := cover.CheckSolution(*ins, *sol); err != nil {
+			data.Problems = strings.Split(err.Error(), "\n")
+		}
+	}
+
+	return reportTemplate.Execute(w, data)
+}
+
+// chartSeries is a line of a chart.
+type chartSeries struct {
+	Name  string
+	Color string
+	X, Y  []float64
+	// If the line is drawn as steps, i.e. each value holds until the next.
+	Step bool
+}
+
+// progressChart charts the incumbent and bound over time.
+func progressChart(records []solvers.NodeRecord) template.HTML {
+	incumbent := chartSeries{Name: "incumbent", Color: "#2a7", Step: true}
+	bound := chartSeries{Name: "bound", Color: "#36c", Step: true}
+	for _, rec := range records {
+		if rec.Incumbent != nil {
+			incumbent.X = append(incumbent.X, rec.Time)
+			incumbent.Y = append(incumbent.Y, *rec.Incumbent)
+		}
+		if rec.Bound != nil {
+			bound.X = append(bound.X, rec.Time)
+			bound.Y = append(bound.Y, *rec.Bound)
+		}
+	}
+	return lineChart(600, 240, "time (s)", []chartSeries{incumbent, bound})
+}
+
+// sparkline is a small chart of the values without axes.
+func sparkline(values []float64) template.HTML {
+	if len(values) < 2 {
+		return ""
+	}
+	x := make([]float64, len(values))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return lineChart(240, 80, "", []chartSeries{{Name: "dual", Color: "#c63", X: x, Y: values}})
+}
+
+// lineChart draws the series as an inline SVG. The axes are labeled with
+// their ranges and xLabel. With an empty xLabel, only the y range is shown.
+func lineChart(width, height float64, xLabel string, series []chartSeries) template.HTML {
+	xMin, xMax := math.Inf(1), math.Inf(-1)
+	yMin, yMax := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for i := range s.X {
+			xMin, xMax = min(xMin, s.X[i]), max(xMax, s.X[i])
+			yMin, yMax = min(yMin, s.Y[i]), max(yMax, s.Y[i])
+		}
+	}
+	if math.IsInf(xMin, 1) {
+		return ""
+	}
+	if xMax == xMin {
+		xMax = xMin + 1
+	}
+	if yMax == yMin {
+		yMax = yMin + 1
+	}
+
+	// The left margin is for the y range and the others for the x range
+	// and legend, if any.
+	const left = 40.0
+	margin := 40.0
+	if xLabel == "" {
+		margin = 8
+	}
+	px := func(x float64) float64 { return left + (x-xMin)/(xMax-xMin)*(width-left-margin) }
+	py := func(y float64) float64 { return height - margin + (yMin-y)/(yMax-yMin)*(height-2*margin) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" class="chart">`, width, height)
+	fmt.Fprintf(&b, `<rect x="%g" y="%g" width="%g" height="%g" class="frame"/>`,
+		left, margin, width-left-margin, height-2*margin)
+	fmt.Fprintf(&b, `<text x="2" y="%g">%.4g</text><text x="2" y="%g">%.4g</text>`,
+		margin+8, yMax, height-margin, yMin)
+	if xLabel != "" {
+		fmt.Fprintf(&b, `<text x="%g" y="%g">%.4g</text><text x="%g" y="%g" text-anchor="end">%.4g</text>`,
+			left, height-margin+15, xMin, width-margin, height-margin+15, xMax)
+		fmt.Fprintf(&b, `<text x="%g" y="%g" text-anchor="middle">%s</text>`,
+			width/2, height-5, template.HTMLEscapeString(xLabel))
+	}
+	legendX := left
+	for _, s := range series {
+		if len(s.X) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" points="`, s.Color)
+		for i := range s.X {
+			if s.Step && i > 0 {
+				fmt.Fprintf(&b, "%.1f,%.1f ", px(s.X[i]), py(s.Y[i-1]))
+			}
+			fmt.Fprintf(&b, "%.1f,%.1f ", px(s.X[i]), py(s.Y[i]))
+		}
+		b.WriteString(`"/>`)
+		if len(series) > 1 {
+			fmt.Fprintf(&b, `<text x="%g" y="%g" fill="%s">%s</text>`,
+				legendX, margin-8, s.Color, template.HTMLEscapeString(s.Name))
+			legendX += 90
+		}
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
@@ -0,0 +1,467 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/solvers"
+	"github.com/snow-abstraction/cover/internal/tree"
+	"github.com/snow-abstraction/cover/internal/util"
+	"github.com/snow-abstraction/cover/presolve"
+)
+
+// solverNames are the names of the solvers used by the -solver flags.
+var solverNames = map[string]solvers.Solver{
+	"bb":    solvers.SolveByBranchAndBound,
+	"brute": solvers.SolveByBruteForce,
+	"dlx":   solvers.SolveByDancingLinks,
+}
+
+var solverDescriptions = map[string]string{
+	"bb":    "branch-and-bound",
+	"brute": "brute-force",
+	"dlx":   "dancing links",
+}
+
+func solverByName(name string) solvers.Solver {
+	solve, found := solverNames[name]
+	if !found {
+		fmt.Fprintf(os.Stderr, "unknown solver %s. The solvers are bb, brute and dlx\n", name)
+		os.Exit(2)
+	}
+	return solve
+}
+
+// The exit statuses of the solve commands.
+const (
+	ExitOptimal    = 0 // an optimal solution was found
+	ExitError      = 1
+	ExitUsage      = 2 // invalid arguments
+	ExitFeasible   = 3 // a solution was found but not proven optimal
+	ExitInfeasible = 4 // the instance was proven infeasible
+	ExitUnknown    = 5 // a limit was reached before any solution was found
+)
+
+const solveUsage = `Usage: %s -instance instance.json
+
+%s reads in a problem instance file, solves it and outputs a solution
+to standard out.
+
+The exit status is 0 if an optimal solution was found, 1 on errors, 2 for
+invalid arguments, 3 if a solution was found but not proven optimal due to
+a limit, 4 if the instance is infeasible and 5 if a limit was reached before
+any solution was found.
+
+Arguments:
+`
+
+// Solve is the solve subcommand.
+func Solve(name string, args []string) {
+	runSolve(name, args, "bb")
+}
+
+// Brute is the solve subcommand using the brute force solver by default.
+func Brute(name string, args []string) {
+	runSolve(name, args, "brute")
+}
+
+// solveRun is the result of solving an instance.
+type solveRun struct {
+	eval  cover.SubsetsEval
+	stats solvers.Stats
+}
+
+func runSolve(name string, args []string, defaultSolver string) {
+	flags := util.NewCommandFlagSet(name, solveUsage)
+	filename := flags.String("instance", "", InstanceFlagUsage)
+	solverName := flags.String("solver", defaultSolver, "solver (bb for branch-and-bound, brute for brute force or dlx for dancing links)")
+	usePresolve := flags.Bool("presolve", false, "reduce the instance using presolve before solving it")
+	decompose := flags.Bool("decompose", false, "solve each connected component of the instance independently")
+	workers := flags.Int("workers", 1, "number of components to solve concurrently when using -decompose")
+	solutionFile := flags.String("solution", "", "if not empty, also write the solution to this file in the solution JSON format")
+	nodeLimit := flags.Int("nodeLimit", 0,
+		"maximum number of branch-and-bound nodes to process (0 means no limit). With -decompose, the limit is\n"+
+			"per component.")
+	timeLimit := flags.Duration("timeLimit", 0,
+		"maximum branch-and-bound time, e.g. 10s (0 means no limit). With -decompose, the limit is of the whole\n"+
+			"solve and the components share it.")
+	progressInterval := flags.Duration("progress", 5*time.Second,
+		"interval between branch-and-bound progress lines logged at Info level. New incumbents are always logged.\n"+
+			"With -decompose, the lines combine the components.")
+	initialFile := flags.String("initial", "", "if not empty, a solution file with an exact cover to start the branch-and-bound search from")
+	checkpointFile := flags.String("checkpoint", "", "if not empty, periodically write a checkpoint of the branch-and-bound search to this file")
+	checkpointInterval := flags.Duration("checkpointInterval", time.Minute, "interval between checkpoints")
+	resumeFile := flags.String("resume", "", "if not empty, resume the branch-and-bound search from this checkpoint file")
+	recordFile := flags.String("record", "", "if not empty, write a record of each branch-and-bound node to this file as JSON Lines")
+	treeFile := flags.String("tree", "", "if not empty, write the branch-and-bound tree to this file in the Graphviz DOT format")
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	opts := solvers.Options{
+		NodeLimit:        *nodeLimit,
+		TimeLimit:        *timeLimit,
+		OnProgress:       solvers.LogProgress(),
+		ProgressInterval: *progressInterval,
+	}
+	var nodes nodeCollector
+	if *treeFile != "" {
+		opts.OnNode = nodes.add
+	}
+	if *recordFile != "" {
+		opts.Recorder = solvers.NewRecorder()
+	}
+	if *initialFile != "" {
+		// The components and presolve renumber the subsets.
+		if *decompose || *usePresolve || *solverName != "bb" {
+			Fatalf("-initial can only be used with the bb solver and without -decompose and -presolve")
+		}
+		initial, err := ReadSolutionFile(*initialFile)
+		if err != nil {
+			Fatalf("failed to read the initial solution due to error: %s", err)
+		}
+		opts.InitialSolution = initial.Solution
+	}
+	if *checkpointFile != "" || *resumeFile != "" {
+		// The checkpoints are of the whole instance.
+		if *decompose || *usePresolve || *solverName != "bb" {
+			Fatalf("-checkpoint and -resume can only be used with the bb solver and without -decompose and -presolve")
+		}
+	}
+	if *checkpointFile != "" {
+		opts.CheckpointInterval = *checkpointInterval
+		opts.OnCheckpoint = func(c solvers.Checkpoint) error {
+			return writeCheckpointFile(*checkpointFile, c)
+		}
+	}
+	if *resumeFile != "" {
+		c, err := readCheckpointFile(*resumeFile)
+		if err != nil {
+			Fatalf("failed to read the checkpoint due to error: %s", err)
+		}
+		opts.Resume = c
+	}
+	solve := solverWithStats(*solverName, opts)
+	ins := ReadInstance(*filename)
+
+	start := time.Now()
+	original := *ins
+	var presolved *presolve.Result
+	if *usePresolve {
+		var err error
+		presolved, err = presolve.Presolve(*ins)
+		if err != nil {
+			Fatalf("failed to presolve instance due to error: %s", err)
+		}
+		slog.Info("presolved", "report", fmt.Sprintf("%+v", presolved.Report),
+			"elements", presolved.Instance.ElementCount, "subsets", len(presolved.Instance.Subsets))
+		ins = &presolved.Instance
+	}
+
+	var run solveRun
+	var err error
+	if *decompose {
+		solveComponent := solve
+		if *solverName == "bb" {
+			// One progress table of the whole instance instead of one per
+			// component interleaved.
+			progress := newCombinedProgress(len(cover.Components(*ins)), *progressInterval, opts.OnProgress)
+			deadline := time.Now().Add(*timeLimit)
+			solveComponent = func(component cover.Instance) (solveRun, error) {
+				componentOpts := opts
+				if *timeLimit > 0 {
+					// A limit of 0 means no limit, so stop at once when the
+					// time is up.
+					componentOpts.TimeLimit = max(time.Until(deadline), time.Nanosecond)
+				}
+				k, onProgress := progress.component()
+				componentOpts.OnProgress = onProgress
+				run, err := solverWithStats(*solverName, componentOpts)(component)
+				progress.finish(k, run)
+				return run, err
+			}
+		}
+		run, err = solveByComponents(*ins, solveComponent, *workers)
+	} else {
+		run, err = solve(*ins)
+	}
+	if err != nil {
+		Fatalf("failed to optimal solution due to error: %s", err)
+	}
+	sol := run.eval
+	if presolved != nil {
+		sol = presolved.Postsolve(sol)
+		run.stats.LowerBound += presolved.ForcedCost
+	}
+
+	s := makeSolution(original, sol, run.stats)
+	s.Statistics = &cover.SolveStatistics{
+		Solver:      solverDescriptions[*solverName],
+		Nodes:       run.stats.Nodes,
+		TimeSeconds: time.Since(start).Seconds(),
+	}
+	if common.JSON() {
+		err = WriteJSON(os.Stdout, s)
+	} else {
+		_, err = fmt.Printf("Solution: %+v\nStatus: %s, bound: %v, nodes: %d, time: %.3fs\n",
+			sol, s.Status, s.Bound, s.Statistics.Nodes, s.Statistics.TimeSeconds)
+		if err == nil && *solverName == "bb" {
+			err = printStats(os.Stdout, run.stats)
+		}
+	}
+	if err != nil {
+		Fatalf("failed to write solution due to error: %s", err)
+	}
+
+	if *solutionFile != "" {
+		if err := WriteSolutionFile(*solutionFile, s); err != nil {
+			Fatalf("failed to write solution due to error: %s", err)
+		}
+	}
+	if *recordFile != "" {
+		if err := writeRecordFile(*recordFile, opts.Recorder); err != nil {
+			Fatalf("failed to write node records due to error: %s", err)
+		}
+	}
+	if *treeFile != "" {
+		if err := nodes.writeDOTFile(*treeFile); err != nil {
+			Fatalf("failed to write tree due to error: %s", err)
+		}
+	}
+	os.Exit(exitStatus(s.Status))
+}
+
+// nodeCollector collects the branch-and-bound nodes. It is safe for
+// concurrent use since the components may be solved concurrently.
+type nodeCollector struct {
+	mu    sync.Mutex
+	nodes []tree.Node
+}
+
+func (c *nodeCollector) add(node tree.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes = append(c.nodes, node)
+}
+
+func (c *nodeCollector) writeDOTFile(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := tree.WriteDOT(f, c.nodes); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// combinedProgress combines the progress of the components solved
+// concurrently into the progress of the whole instance and passes it on. It is
+// logged like the progress of a single run: on new incumbents and at least
+// every interval. The bounds of the components not started yet are taken to
+// be 0 since all costs are positive.
+type combinedProgress struct {
+	mu         sync.Mutex
+	total      int
+	interval   time.Duration
+	start      time.Time
+	lastLogged time.Duration
+	components []solvers.Progress
+	onProgress func(solvers.Progress)
+}
+
+func newCombinedProgress(total int, interval time.Duration, onProgress func(solvers.Progress)) *combinedProgress {
+	return &combinedProgress{total: total, interval: interval, start: time.Now(), onProgress: onProgress}
+}
+
+// component returns the index of a newly started component and the
+// Options.OnProgress callback for it.
+func (c *combinedProgress) component() (int, func(solvers.Progress)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := len(c.components)
+	c.components = append(c.components, solvers.Progress{Incumbent: math.Inf(1)})
+	return k, func(p solvers.Progress) { c.update(k, p) }
+}
+
+// finish records the final state of component k since its last progress may
+// be out of date.
+func (c *combinedProgress) finish(k int, run solveRun) {
+	p := solvers.Progress{Nodes: run.stats.Nodes, Bound: run.stats.LowerBound, Incumbent: math.Inf(1)}
+	if run.eval.ExactlyCovered {
+		p.Incumbent = run.eval.Cost
+	}
+	c.update(k, p)
+}
+
+func (c *combinedProgress) update(k int, p solvers.Progress) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components[k] = p
+
+	combined := solvers.Progress{Elapsed: time.Since(c.start)}
+	for _, cp := range c.components {
+		combined.Nodes += cp.Nodes
+		combined.OpenNodes += cp.OpenNodes
+		combined.Bound += cp.Bound
+		combined.Incumbent += cp.Incumbent
+	}
+	// The whole instance only has an exact cover once every component has one.
+	if len(c.components) < c.total {
+		combined.Incumbent = math.Inf(1)
+	}
+	combined.Gap = math.Inf(1)
+	if !math.IsInf(combined.Incumbent, 1) {
+		combined.Gap = (combined.Incumbent - combined.Bound) / combined.Incumbent
+		combined.NewIncumbent = p.NewIncumbent
+	}
+
+	due := c.interval > 0 && combined.Elapsed-c.lastLogged >= c.interval
+	if !combined.NewIncumbent && !due {
+		return
+	}
+	c.lastLogged = combined.Elapsed
+	c.onProgress(combined)
+}
+
+// writeCheckpointFile writes the checkpoint to a temporary file and renames
+// it so the checkpoint file is complete even if the process is killed.
+func writeCheckpointFile(filename string, c solvers.Checkpoint) error {
+	tmp := filename + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := solvers.WriteCheckpoint(f, c); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	slog.Debug("wrote checkpoint", "file", filename, "open nodes", len(c.Open))
+	return os.Rename(tmp, filename)
+}
+
+func readCheckpointFile(filename string) (*solvers.Checkpoint, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return solvers.ReadCheckpoint(f)
+}
+
+func writeRecordFile(filename string, recorder *solvers.Recorder) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := recorder.WriteJSONLines(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// solverWithStats returns the named solver. Only the branch-and-bound solver
+// uses the options and has statistics.
+func solverWithStats(name string, opts solvers.Options) func(cover.Instance) (solveRun, error) {
+	if name == "bb" {
+		return func(ins cover.Instance) (solveRun, error) {
+			eval, stats, err := solvers.SolveByBranchAndBoundWithOptions(ins, opts)
+			return solveRun{eval, stats}, err
+		}
+	}
+
+	solve := solverByName(name)
+	return func(ins cover.Instance) (solveRun, error) {
+		eval, err := solve(ins)
+		stats := solvers.Stats{LowerBound: math.Inf(1)}
+		if eval.Optimal {
+			stats.LowerBound = eval.Cost
+		}
+		return solveRun{eval, stats}, err
+	}
+}
+
+// solveByComponents solves the components using solvers.SolveByComponents
+// and combines the statistics of the components.
+func solveByComponents(ins cover.Instance, solve func(cover.Instance) (solveRun, error), workers int) (solveRun, error) {
+	var mu sync.Mutex
+	var stats solvers.Stats
+	eval, err := solvers.SolveByComponents(ins, func(component cover.Instance) (cover.SubsetsEval, error) {
+		run, err := solve(component)
+		mu.Lock()
+		defer mu.Unlock()
+		stats.AddComponent(run.stats)
+		return run.eval, err
+	}, workers)
+	return solveRun{eval, stats}, err
+}
+
+// printStats prints the branch-and-bound statistics.
+func printStats(w io.Writer, stats solvers.Stats) error {
+	_, err := fmt.Fprintf(w, "Nodes: %d pruned, %d infeasible, %d integral, %d branched, at most %d open\n"+
+		"Subgradient: %d runs, %d iterations (mean %.1f), root bound: %v\n"+
+		"Time: sub-instances %.3fs, subgradient %.3fs, branching %.3fs\n",
+		stats.PrunedNodes, stats.InfeasibleNodes, stats.IntegralNodes, stats.BranchedNodes, stats.MaxOpenNodes,
+		stats.DualRuns, stats.SubgradientIterations, stats.MeanSubgradientIterations(), stats.RootBound,
+		stats.SubInstanceTime.Seconds(), stats.DualTime.Seconds(), stats.BranchingTime.Seconds())
+	return err
+}
+
+// makeSolution makes the solution taking into account if a limit was reached.
+func makeSolution(ins cover.Instance, eval cover.SubsetsEval, stats solvers.Stats) cover.Solution {
+	s := cover.NewSolution(ins, eval)
+	if !stats.LimitReached {
+		return s
+	}
+
+	switch s.Status {
+	case cover.StatusInfeasible:
+		// Not proven since the search was not completed.
+		s.Status = cover.StatusUnknown
+		s.Bound = stats.LowerBound
+	case cover.StatusFeasible:
+		s.Bound = stats.LowerBound
+		s.Gap = (s.Cost - s.Bound) / s.Cost
+	}
+	return s
+}
+
+func exitStatus(status cover.SolutionStatus) int {
+	switch status {
+	case cover.StatusOptimal:
+		return ExitOptimal
+	case cover.StatusFeasible:
+		return ExitFeasible
+	case cover.StatusInfeasible:
+		return ExitInfeasible
+	}
+	return ExitUnknown
+}
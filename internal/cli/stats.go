@@ -0,0 +1,117 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+// maxListed is the maximum number of elements or subsets listed in the text
+// output.
+const maxListed = 20
+
+// Stats is the stats subcommand.
+func Stats(name string, args []string) {
+	flags := util.NewCommandFlagSet(name, `Usage: %s -instance instance.json
+
+%s reads in a problem instance file and outputs an analysis of it: counts,
+density, subset size and element frequency histograms, the cost
+distribution, duplicate subsets, elements in only one subset or in none and
+the connected components. See cover.Analyze.
+
+Arguments:
+`)
+	filename := flags.String("instance", "", InstanceFlagUsage)
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	ins := ReadInstance(*filename)
+	a := cover.Analyze(*ins)
+	if common.JSON() {
+		if err := WriteJSON(os.Stdout, a); err != nil {
+			Fatalf("failed to write statistics due to error: %s", err)
+		}
+		return
+	}
+	printAnalysis(os.Stdout, *ins, a)
+}
+
+func printAnalysis(out io.Writer, ins cover.Instance, a cover.Analysis) {
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "elements:\t%d\n", a.ElementCount)
+	fmt.Fprintf(w, "subsets:\t%d\n", a.SubsetCount)
+	fmt.Fprintf(w, "nonzeros:\t%d\n", a.Nonzeros)
+	fmt.Fprintf(w, "density:\t%.4g\n", a.Density)
+	fmt.Fprintf(w, "subset sizes (size:count):\t%s\n", formatHistogram(a.SubsetSizeHistogram))
+	fmt.Fprintf(w, "element frequencies (subsets:count):\t%s\n", formatHistogram(a.ElementFrequencyHistogram))
+	c := a.Costs
+	fmt.Fprintf(w, "costs:\tmin %v, max %v, mean %.6g, median %v, standard deviation %.6g\n",
+		c.Min, c.Max, c.Mean, c.Median, c.StdDev)
+	fmt.Fprintf(w, "distinct costs:\t%d (integral: %t)\n", c.Distinct, c.Integral)
+	fmt.Fprintf(w, "duplicate subset groups:\t%d (%d redundant subsets)\n",
+		len(a.DuplicateSubsets), a.RedundantDuplicates)
+	fmt.Fprintf(w, "elements in one subset:\t%d%s\n",
+		len(a.SingletonElements), formatList(a.SingletonElements, ins.ElementName))
+	fmt.Fprintf(w, "elements in no subset:\t%d%s\n",
+		len(a.UncoveredElements), formatList(a.UncoveredElements, ins.ElementName))
+	fmt.Fprintf(w, "components:\t%d (largest: %d elements, %d subsets)\n",
+		a.ComponentCount, a.LargestComponentElements, a.LargestComponentSubsets)
+	w.Flush()
+
+	if len(a.UncoveredElements) > 0 {
+		fmt.Fprintln(out, "The instance is infeasible since some elements are in no subset.")
+	}
+}
+
+// formatHistogram formats the nonzero entries as k:count.
+func formatHistogram(histogram []int) string {
+	var parts []string
+	for k, count := range histogram {
+		if count > 0 {
+			parts = append(parts, fmt.Sprintf("%d:%d", k, count))
+		}
+	}
+	if parts == nil {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatList formats up to maxListed of the names of the indices.
+func formatList(indices []int, name func(int) string) string {
+	if len(indices) == 0 {
+		return ""
+	}
+	names := make([]string, 0, min(len(indices), maxListed))
+	for _, idx := range indices[:min(len(indices), maxListed)] {
+		names = append(names, name(idx))
+	}
+	s := " (" + strings.Join(names, ", ")
+	if len(indices) > maxListed {
+		s += ", ..."
+	}
+	return s + ")"
+}
@@ -0,0 +1,160 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/util"
+)
+
+// verifyResult is the JSON output of the verify subcommand.
+type verifyResult struct {
+	cover.Verification
+	Status      cover.SolutionStatus `json:",omitempty"`
+	ClaimedCost *float64             `json:",omitempty"`
+	Problems    []string             `json:",omitempty"`
+	Valid       bool
+}
+
+// Verify is the verify subcommand.
+func Verify(name string, args []string) {
+	flags := util.NewCommandFlagSet(name, `Usage: %s -instance instance.json -solution solution.json
+
+%s recomputes the coverage and cost of a solution to a problem instance without
+using the solvers and reports every over-covered and under-covered element,
+invalid or repeated subset index and cost mismatch. The solution is either a
+solution file, as written by solve -solution or tools/solve_sc.py, or a
+list of subset indices. The exit status is 0 if the solution is valid and
+1 otherwise.
+
+Arguments:
+`)
+	filename := flags.String("instance", "", InstanceFlagUsage)
+	solutionFile := flags.String("solution", "", "solution filename")
+	subsets := flags.String("subsets", "", "comma separated subset indices, e.g. 0,4,7, to verify instead of a solution file")
+	common := AddCommonFlags(flags)
+	flags.ParseArgs(args)
+	common.Setup()
+
+	if (*solutionFile == "") == (*subsets == "") {
+		Fatalf("Please supply either the solution file name or the subset indices")
+	}
+	ins := ReadInstance(*filename)
+
+	var sol *cover.Solution
+	var indices []int
+	var err error
+	if *solutionFile != "" {
+		sol, err = ReadSolutionFile(*solutionFile)
+		if err != nil {
+			Fatalf("failed to read solution due to error: %s", err)
+		}
+		indices = sol.Solution
+	} else {
+		indices, err = parseIndices(*subsets)
+		if err != nil {
+			Fatalf("failed to parse subset indices due to error: %s", err)
+		}
+	}
+
+	result := verifyResult{Verification: cover.Verify(*ins, indices)}
+	v := result.Verification
+	result.Valid = v.ExactlyCovered && len(v.DuplicateIndices) == 0
+	if sol != nil {
+		result.Status = sol.Status
+		result.ClaimedCost = &sol.Cost
+		err := cover.CheckSolution(*ins, *sol)
+		result.Valid = err == nil
+		if err != nil {
+			result.Problems = strings.Split(err.Error(), "\n")
+		}
+	}
+
+	if common.JSON() {
+		if err := WriteJSON(os.Stdout, result); err != nil {
+			Fatalf("failed to write the result due to error: %s", err)
+		}
+	} else {
+		printVerification(*ins, result, sol)
+	}
+	if !result.Valid {
+		os.Exit(1)
+	}
+}
+
+func printVerification(ins cover.Instance, result verifyResult, sol *cover.Solution) {
+	v := result.Verification
+	// A solution without subsets claiming infeasibility covers nothing so
+	// listing the uncovered elements is noise.
+	if sol == nil || len(sol.Solution) > 0 || sol.Status == cover.StatusOptimal || sol.Status == cover.StatusFeasible {
+		fmt.Printf("cost: %v\n", v.Cost)
+		fmt.Printf("exactly covered: %t\n", v.ExactlyCovered)
+		for _, i := range v.OverCovered {
+			fmt.Printf("over-covered element %d (%s): covered %d times\n", i, ins.ElementName(i), v.Coverage[i])
+		}
+		for _, i := range v.UnderCovered {
+			fmt.Printf("under-covered element %d (%s): not covered\n", i, ins.ElementName(i))
+		}
+		for _, j := range v.InvalidIndices {
+			fmt.Printf("invalid subset index %d: not in [0, %d)\n", j, len(ins.Subsets))
+		}
+		for _, j := range v.DuplicateIndices {
+			fmt.Printf("repeated subset %d (%s)\n", j, ins.SubsetName(j))
+		}
+		for _, i := range v.InvalidElements {
+			fmt.Printf("invalid element %d: not in [0, %d)\n", i, ins.ElementCount)
+		}
+	}
+
+	if sol != nil {
+		fmt.Printf("status: %s\n", sol.Status)
+		fmt.Printf("claimed cost: %v\n", sol.Cost)
+		if !v.CostMatches(sol.Cost) {
+			fmt.Printf("cost mismatch: the claimed cost is %v but the recomputed cost is %v\n", sol.Cost, v.Cost)
+		}
+		if len(result.Problems) > 0 {
+			fmt.Println("solution problems:")
+			for _, line := range result.Problems {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	}
+
+	if result.Valid {
+		fmt.Println("VALID")
+	} else {
+		fmt.Println("INVALID")
+	}
+}
+
+func parseIndices(s string) ([]int, error) {
+	var indices []int
+	for _, field := range strings.Split(s, ",") {
+		idx, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse '%s' as a subset index", field)
+		}
+		indices = append(indices, idx)
+	}
+	return indices, nil
+}
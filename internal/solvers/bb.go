@@ -20,11 +20,13 @@
 package solvers
 
 import (
-	"cmp"
 	"fmt"
 	"log/slog"
+	"math"
 	"slices"
+	"time"
 
+	"github.com/snow-abstraction/cover"
 	"github.com/snow-abstraction/cover/internal/solvers/queue"
 	"github.com/snow-abstraction/cover/internal/tree"
 )
@@ -36,29 +38,36 @@ type subInstance struct {
 	isSolution bool
 }
 
+// constraint is the branching constraint of a node.
+type constraint struct {
+	i            uint32
+	j            uint32
+	isBothBranch bool // if not, is different branch
+}
+
+// allows reports if the subset, whose elements are sorted, satisfies the
+// constraint.
+func (c constraint) allows(subset []int) bool {
+	// TODO: check these int casts or eliminate them
+	_, hasI := slices.BinarySearch(subset, int(c.i))
+	_, hasJ := slices.BinarySearch(subset, int(c.j))
+	if c.isBothBranch {
+		return hasI == hasJ
+	}
+	return !(hasI && hasJ)
+}
+
 // createSubInstance creates new instance with only the subsets that are allowed by the
 // constraints from the node and its ancestors. If some element is
 // impossible to cover then it returns nil.
-func createSubInstance(ins instance, node *tree.Node) (*subInstance, error) {
-	type constraint struct {
-		i            uint32
-		j            uint32
-		isBothBranch bool // if not, is different branch
-	}
-
-	costs := make([]float64, 0, len(ins.costs))
-	subsets := make([][]int, 0, len(ins.subsets))
-	// count of the subsets in sub-instance
-	coverCount := make([]int, ins.m)
-	indices := make([]int, 0, len(ins.subsets))
-
+func createSubInstance(ins instance, node tree.Node) (*subInstance, error) {
 	branchedIndices := make(map[BranchIndices]struct{})
 
 	constraints := make([]constraint, 0)
-	for nodeI := node; nodeI.Kind != tree.Root; nodeI = nodeI.Parent {
-		isBothBranch := nodeI.Kind == tree.BothBranch
-		c := constraint{i: nodeI.I, j: nodeI.J, isBothBranch: isBothBranch}
-		b := BranchIndices{nodeI.I, nodeI.J}
+	for nodeI := node; nodeI.Kind() != tree.Root; nodeI = nodeI.Parent() {
+		isBothBranch := nodeI.Kind() == tree.BothBranch
+		c := constraint{i: nodeI.I(), j: nodeI.J(), isBothBranch: isBothBranch}
+		b := BranchIndices{nodeI.I(), nodeI.J()}
 		if b.i >= b.j {
 			return nil, fmt.Errorf("should have i < j but %+v", b)
 		}
@@ -70,22 +79,62 @@ func createSubInstance(ins instance, node *tree.Node) (*subInstance, error) {
 		constraints = append(constraints, c)
 	}
 
-	for i, subset := range ins.subsets {
+	return filterSubsets(ins, nil, constraints), nil
+}
+
+// createChildSubInstance is createSubInstance for a node whose parent's
+// sub-instance has the subsets candidates. Since the candidates satisfy the
+// ancestors' constraints, only the node's own constraint is checked and the
+// ancestors are not walked.
+//
+// Thus the ancestors are not checked for having branched on the same pair as
+// createSubInstance does. That can not happen: findBranchingElements picks a
+// pair such that a subset has both elements and another subset has only one
+// of them, so the node's constraint removes at least one candidate. After the
+// constraint, no candidate of the descendants has only one of the elements
+// (a "both" branch) or both (a "diff" branch), so the pair can not be picked
+// again. This is asserted by checking that some candidate was removed.
+func createChildSubInstance(ins instance, node tree.Node, candidates []int32) (*subInstance, error) {
+	if node.Kind() == tree.Root {
+		return nil, fmt.Errorf("the root node has no parent sub-instance")
+	}
+	b := BranchIndices{node.I(), node.J()}
+	if b.i >= b.j {
+		return nil, fmt.Errorf("should have i < j but %+v", b)
+	}
+	c := constraint{i: b.i, j: b.j, isBothBranch: node.Kind() == tree.BothBranch}
+	sub := filterSubsets(ins, candidates, []constraint{c})
+	if sub != nil && len(sub.indices) == len(candidates) {
+		return nil, fmt.Errorf("branching on %+v removed no subsets so it was already branched on", b)
+	}
+	return sub, nil
+}
+
+// filterSubsets creates the sub-instance of the candidate subsets, or all
+// subsets if candidates is nil, that satisfy the constraints. If some element
+// is impossible to cover then it returns nil.
+func filterSubsets(ins instance, candidates []int32, constraints []constraint) *subInstance {
+	n := len(ins.subsets)
+	if candidates != nil {
+		n = len(candidates)
+	}
+	costs := make([]float64, 0, n)
+	subsets := make([][]int, 0, n)
+	// count of the subsets in sub-instance
+	coverCount := make([]int32, ins.m)
+	indices := make([]int, 0, n)
+
+	for k := 0; k < n; k++ {
+		i := k
+		if candidates != nil {
+			i = int(candidates[k])
+		}
+		subset := ins.subsets[i]
 		noConstraintsViolated := true
 		for _, c := range constraints {
-			// TODO: check these int casts or eliminate them
-			hasI := slices.Contains(subset, int(c.i))
-			hasJ := slices.Contains(subset, int(c.j))
-			if c.isBothBranch {
-				if hasI != hasJ {
-					noConstraintsViolated = false
-					break
-				}
-			} else {
-				if hasI && hasJ {
-					noConstraintsViolated = false
-					break
-				}
+			if !c.allows(subset) {
+				noConstraintsViolated = false
+				break
 			}
 		}
 		if noConstraintsViolated {
@@ -93,9 +142,7 @@ func createSubInstance(ins instance, node *tree.Node) (*subInstance, error) {
 			costs = append(costs, ins.costs[i])
 			subsets = append(subsets, subset)
 			for _, e := range subset {
-				// TODO: check these int casts or eliminate them
-				idx := int(e)
-				coverCount[idx]++
+				coverCount[e]++
 			}
 		}
 	}
@@ -103,7 +150,7 @@ func createSubInstance(ins instance, node *tree.Node) (*subInstance, error) {
 	isSolution := true // If the sub-instance is a solution.
 	for _, covered := range coverCount {
 		if covered == 0 {
-			return nil, nil
+			return nil
 		} else if covered > 1 {
 			isSolution = false
 		}
@@ -113,8 +160,37 @@ func createSubInstance(ins instance, node *tree.Node) (*subInstance, error) {
 		ins:        instance{m: ins.m, subsets: subsets, costs: costs},
 		indices:    indices,
 		isSolution: isSolution,
-	}, nil
+	}
+}
 
+// maxHeldCandidates caps the candidates held for the open nodes. With 4 bytes
+// per candidate this is 4 MiB. It is a variable so that the tests can turn
+// the candidates off.
+//
+// The candidates of the two children of a branched node are the subsets of
+// its sub-instance. They are stored once per branched node and released when
+// both children have been popped. They usually take far more memory than the
+// nodes, see tree.Tree, but in return the children's sub-instances are made
+// faster and with fewer allocations, see BenchmarkCreateChildSubInstance and
+// BenchmarkBBPeakMemory. Beyond the cap, the children's sub-instances are made
+// by createSubInstance.
+var maxHeldCandidates = 1 << 20
+
+// sharedCandidates are the candidates of the children of a branched node and
+// the number of them not yet popped.
+type sharedCandidates struct {
+	candidates []int32
+	pending    int
+}
+
+// candidatesOf is the candidate subsets of the children of the node of the
+// sub-instance, i.e. the sub-instance's subsets.
+func candidatesOf(sub *subInstance) []int32 {
+	candidates := make([]int32, len(sub.indices))
+	for k, i := range sub.indices {
+		candidates[k] = int32(i)
+	}
+	return candidates
 }
 
 type solution struct {
@@ -134,143 +210,387 @@ func sum(xs []float64) float64 {
 // with more expensive duplicates removed and originalIndexMap []int
 // that maps indices of the new instance back ins. That is:
 // ins.subset[originalIndexMap[i[]] == insCopy.subset[i].
+// See cover.RemoveMoreExpensiveDuplicates.
 func removeMoreExpensiveDuplicates(ins instance) (instance, []int) {
-	type SubsetCostIndex struct {
-		subset []int
-		cost   float64
-		index  int
-	}
+	reduced, originalIndexMap := cover.RemoveMoreExpensiveDuplicates(
+		cover.Instance{ElementCount: ins.m, Subsets: ins.subsets, Costs: ins.costs})
+	return instance{reduced.ElementCount, reduced.Subsets, reduced.Costs}, originalIndexMap
+}
 
-	temp := make([]SubsetCostIndex, 0, len(ins.subsets))
-	for i := 0; i < len(ins.subsets); i++ {
-		temp = append(temp, SubsetCostIndex{ins.subsets[i], ins.costs[i], i})
-	}
+// WIP
+func SolveByBranchAndBoundInternal(ins instance) (subsetsEval, error) {
+	sol, _, err := solveByBranchAndBound(ins, Options{})
+	return sol, err
+}
 
-	slices.SortFunc(temp, func(lhs, rhs SubsetCostIndex) int {
-		if c := slices.Compare(lhs.subset, rhs.subset); c != 0 {
-			return c
+// solveByBranchAndBound solves the instance while respecting the limits of
+// the options. If a limit is reached, the best solution found so far, if any,
+// is returned and Stats.LimitReached is true.
+func solveByBranchAndBound(ins instance, opts Options) (subsetsEval, Stats, error) {
+	start := time.Now()
+	stats := Stats{LowerBound: math.Inf(1)}
+	var initial *solution
+	if opts.InitialSolution != nil {
+		cost, err := checkInitialSolution(ins, "initial solution", opts.InitialSolution)
+		if err != nil {
+			return subsetsEval{}, stats, err
 		}
-		// Make lowest cost duplicate subset is first.
-		return cmp.Compare(lhs.cost, rhs.cost)
-	})
-
-	ins.subsets = make([][]int, 0, len(ins.subsets))
-	ins.costs = make([]float64, 0, len(ins.costs))
-	originalIndexMap := make([]int, 0, len(ins.subsets))
-
-	for i := 0; i < len(temp); {
-		x := temp[i]
-		ins.subsets = append(ins.subsets, x.subset)
-		ins.costs = append(ins.costs, x.cost)
-		originalIndexMap = append(originalIndexMap, x.index)
-
-		i++
-		for i < len(temp) && slices.Equal(x.subset, temp[i].subset) {
-			i++
+		initial = &solution{cost, slices.Clone(opts.InitialSolution)}
+	}
+	var hash string
+	if opts.OnCheckpoint != nil || opts.Resume != nil {
+		var err error
+		hash, err = InstanceHash(cover.Instance{ElementCount: ins.m, Subsets: ins.subsets, Costs: ins.costs})
+		if err != nil {
+			return subsetsEval{}, stats, err
 		}
 	}
-
-	return ins, originalIndexMap
-}
-
-// WIP
-func SolveByBranchAndBoundInternal(ins instance) (subsetsEval, error) {
+	var resumed []tree.Node
+	if opts.Resume != nil {
+		if opts.Resume.InstanceHash != hash {
+			return subsetsEval{}, stats, ErrCheckpointMismatch
+		}
+		var err error
+		resumed, err = restoreNodes(opts.Resume.Open)
+		if err != nil {
+			return subsetsEval{}, stats, err
+		}
+		if inc := opts.Resume.Incumbent; inc != nil {
+			// The instance hash does not cover the incumbent.
+			cost, err := checkInitialSolution(ins, "checkpoint incumbent", inc.Subsets)
+			if err != nil {
+				return subsetsEval{}, stats, err
+			}
+			if !cover.CostsEqual(cost, inc.Cost) {
+				return subsetsEval{}, stats, fmt.Errorf(
+					"checkpoint incumbent: the cost is %v but the subsets' costs sum to %v", inc.Cost, cost)
+			}
+			if initial == nil || cost < initial.objectiveValue {
+				initial = &solution{cost, slices.Clone(inc.Subsets)}
+			}
+		}
+		stats.Nodes = opts.Resume.Nodes
+	}
 	if ins.m == 0 {
+		stats.LowerBound = 0
 		return subsetsEval{
 			ExactlyCovered: true,
 			Optimal:        true,
-		}, nil
+		}, stats, nil
 	}
 
 	// More expensive duplicates should never been in an optima and branching
 	// scheme does not support duplicates.
 	ins, originalIndexMap := removeMoreExpensiveDuplicates(ins)
 
-	var best *solution
+	// The initial solution's indices are of the original instance and not of
+	// the instance without duplicates.
+	best := initial
 	toFathom := queue.MakeQueue()
-	root := tree.CreateRoot()
-	toFathom.Push(root)
+	if opts.Resume != nil {
+		for _, node := range resumed {
+			toFathom.Push(node)
+		}
+	} else {
+		toFathom.Push(tree.CreateRoot())
+	}
+	// globalBound is the current lower bound on the optimal cost.
+	globalBound := func() float64 {
+		if stats.LimitReached {
+			// The open nodes are being drained so the queue no longer gives
+			// the bound.
+			return stats.LowerBound
+		}
+		bound := math.Inf(1)
+		if best != nil {
+			bound = best.objectiveValue
+		}
+		if toFathom.Len() > 0 {
+			// The open nodes' lower bounds are their parents' dual bounds. The
+			// root has no parent but all costs are positive.
+			if open := toFathom.Peek(); open.Kind() != tree.Root {
+				bound = min(bound, open.LowerBound())
+			} else {
+				bound = 0
+			}
+		}
+		return bound
+	}
+
+	lastProgress := start
+	progress := func(newIncumbent bool) {
+		if opts.OnProgress == nil {
+			return
+		}
+		lastProgress = time.Now()
+		p := Progress{
+			Nodes:        stats.Nodes,
+			OpenNodes:    toFathom.Len(),
+			Bound:        globalBound(),
+			Incumbent:    math.Inf(1),
+			Gap:          math.Inf(1),
+			Elapsed:      lastProgress.Sub(start),
+			NewIncumbent: newIncumbent,
+		}
+		if best != nil {
+			p.Incumbent = best.objectiveValue
+			p.Gap = 0
+			if p.Incumbent > 0 {
+				p.Gap = (p.Incumbent - p.Bound) / p.Incumbent
+			}
+		}
+		opts.OnProgress(p)
+	}
+
+	// improved reports that a better solution was found.
+	improved := func() {
+		progress(true)
+		if opts.OnIncumbent != nil {
+			opts.OnIncumbent(mapIndices(best.subsetIndices, originalIndexMap), best.objectiveValue)
+		}
+	}
+
+	lastCheckpoint := start
+	checkpoint := func() error {
+		lastCheckpoint = time.Now()
+		c := Checkpoint{
+			InstanceHash: hash,
+			Nodes:        stats.Nodes,
+			Open:         make([]CheckpointNode, 0, toFathom.Len()),
+		}
+		if best != nil {
+			indices := best.subsetIndices
+			if best != initial {
+				indices = mapIndices(indices, originalIndexMap)
+			}
+			c.Incumbent = &CheckpointSolution{best.objectiveValue, indices}
+		}
+		for _, node := range toFathom.Nodes() {
+			c.Open = append(c.Open, makeCheckpointNode(node))
+		}
+		return opts.OnCheckpoint(c)
+	}
 
+	// fathomed sets the outcome of the node and reports it. sub and dual are
+	// for the recorder and may be nil.
+	fathomed := func(node tree.Node, outcome tree.Outcome, sub *subInstance, dual *lagrangianDualResult) {
+		node.SetOutcome(outcome)
+		switch outcome {
+		case tree.Pruned:
+			stats.PrunedNodes++
+		case tree.Infeasible:
+			stats.InfeasibleNodes++
+		case tree.Integral:
+			stats.IntegralNodes++
+		case tree.Branched:
+			stats.BranchedNodes++
+		}
+		if node.Kind() == tree.Root {
+			switch {
+			case outcome == tree.Infeasible:
+				stats.RootBound = math.Inf(1)
+			case dual != nil:
+				stats.RootBound = dual.dualObjectiveValue
+			case sub != nil && sub.isSolution:
+				stats.RootBound = sum(sub.ins.costs)
+			}
+		}
+		if opts.Recorder != nil {
+			var incumbent *float64
+			if best != nil {
+				cost := best.objectiveValue
+				incumbent = &cost
+			}
+			opts.Recorder.record(node, sub, dual, incumbent, globalBound())
+		}
+		if opts.OnNode != nil {
+			opts.OnNode(node)
+		}
+	}
+
+	// The candidates by branched node and their total length.
+	held := make(map[tree.Node]*sharedCandidates)
+	heldCount := 0
+	// takeCandidates returns the candidates of the popped node, which are nil
+	// for the root, a node resumed from a checkpoint or a node branched beyond
+	// maxHeldCandidates.
+	takeCandidates := func(node tree.Node) []int32 {
+		shared, found := held[node.Parent()]
+		if !found {
+			return nil
+		}
+		shared.pending--
+		if shared.pending == 0 {
+			delete(held, node.Parent())
+			heldCount -= len(shared.candidates)
+		}
+		return shared.candidates
+	}
+
+	stats.MaxOpenNodes = toFathom.Len()
+	limitReached := false
 	for toFathom.Len() > 0 {
+		if opts.NodeLimit > 0 && stats.Nodes >= opts.NodeLimit ||
+			opts.TimeLimit > 0 && time.Since(start) >= opts.TimeLimit {
+			limitReached = true
+			break
+		}
+		if opts.ProgressInterval > 0 && time.Since(lastProgress) >= opts.ProgressInterval {
+			progress(false)
+		}
+		if opts.OnCheckpoint != nil && opts.CheckpointInterval > 0 &&
+			time.Since(lastCheckpoint) >= opts.CheckpointInterval {
+			if err := checkpoint(); err != nil {
+				return subsetsEval{}, stats, err
+			}
+		}
+
 		node := toFathom.Pop()
+		candidates := takeCandidates(node)
 		slog.Debug("B&B status", "nodes count", toFathom.Len(), "node", node)
 
-		if best != nil && best.objectiveValue <= node.LowerBound {
+		// The root's lower bound is a placeholder and with an initial
+		// solution there is a best solution before the root is processed.
+		if best != nil && node.Kind() != tree.Root && best.objectiveValue <= node.LowerBound() {
 			slog.Debug("discarding node", "node", node, "best obj val", best.objectiveValue)
 			// discard node due to lower bound
+			fathomed(node, tree.Pruned, nil, nil)
 			continue
 		}
 
-		subInstance, err := createSubInstance(ins, node)
+		stats.Nodes++
+		subInstanceStart := time.Now()
+		var subInstance *subInstance
+		var err error
+		if candidates != nil {
+			subInstance, err = createChildSubInstance(ins, node, candidates)
+		} else {
+			subInstance, err = createSubInstance(ins, node)
+		}
+		stats.SubInstanceTime += time.Since(subInstanceStart)
 		if err != nil {
-			return subsetsEval{}, err
+			return subsetsEval{}, stats, err
 		}
 		if subInstance == nil {
 			slog.Debug("sub-instance infeasible")
+			fathomed(node, tree.Infeasible, nil, nil)
 			continue
 		} else if subInstance.isSolution {
 			cost := sum(subInstance.ins.costs)
 			if best == nil || best.objectiveValue > cost {
 				best = &solution{cost, subInstance.indices}
 				slog.Debug("new best solution from sub-instance", "solution", best)
+				improved()
 			}
+			fathomed(node, tree.Integral, subInstance, nil)
 			continue
 		}
 
 		// Here we know that subInstance either has no solution or has
 		// non-trivial solution in the sense at least element is in two
 		// or more subsets.
+		dualStart := time.Now()
 		matrix, err := convertSubsetsToMatrix(subInstance.ins.subsets)
 		if err != nil {
-			return subsetsEval{}, err
+			return subsetsEval{}, stats, err
 		}
 
 		dualResult, err := runDualIterations(matrix, subInstance.ins.costs)
 		if err != nil {
-			return subsetsEval{}, err
+			return subsetsEval{}, stats, err
 		}
+		stats.DualTime += time.Since(dualStart)
+		stats.DualRuns++
+		stats.SubgradientIterations += dualResult.iterations
 		if dualResult.provenOptimalExact {
 			slog.Debug("pruned by optimal")
 			if best == nil || best.objectiveValue > dualResult.dualObjectiveValue {
 				best = &solution{dualResult.dualObjectiveValue,
 					mapIndices(dualResult.primalSolution, subInstance.indices)}
 				slog.Debug("new best solution", "solution", best)
+				improved()
 			}
+			fathomed(node, tree.Integral, subInstance, &dualResult)
 			continue
 		}
 
 		if best != nil && best.objectiveValue <= dualResult.dualObjectiveValue {
 			// We could only do this when getting the node.
 			slog.Debug("pruned by bound", "node", node)
+			fathomed(node, tree.Pruned, subInstance, &dualResult)
 			continue
 		}
 
+		branchingStart := time.Now()
 		branchIndices, err := findBranchingElements(subInstance.ins)
 		if err != nil {
-			return subsetsEval{}, err
+			return subsetsEval{}, stats, err
 		}
 
 		slog.Debug("branching on elements", "i", branchIndices.i, "j", branchIndices.j)
 		bothNode, diffNode := node.Branch(dualResult.dualObjectiveValue, branchIndices.i, branchIndices.j)
+		if heldCount+len(subInstance.indices) <= maxHeldCandidates {
+			held[node] = &sharedCandidates{candidatesOf(subInstance), 2}
+			heldCount += len(subInstance.indices)
+		}
 		toFathom.Push(bothNode)
 		toFathom.Push(diffNode)
-
+		stats.MaxOpenNodes = max(stats.MaxOpenNodes, toFathom.Len())
+		stats.BranchingTime += time.Since(branchingStart)
+		fathomed(node, tree.Branched, subInstance, &dualResult)
 	}
 
-	// TODO: return non-optimal solutions
-	if best == nil || toFathom.Len() != 0 {
-		return subsetsEval{}, nil
+	stats.Time = time.Since(start)
+	// The bound is taken before the open nodes are drained below.
+	stats.LowerBound = globalBound()
+	stats.LimitReached = limitReached
+	if stats.LimitReached {
+		if opts.OnCheckpoint != nil {
+			if err := checkpoint(); err != nil {
+				return subsetsEval{}, stats, err
+			}
+		}
+		if opts.OnNode != nil || opts.Recorder != nil {
+			for toFathom.Len() > 0 {
+				fathomed(toFathom.Pop(), tree.Open, nil, nil)
+			}
+		}
+	}
+	if best == nil {
+		return subsetsEval{}, stats, nil
 	}
 
 	// map indices back original instance indices
-	indices := mapIndices(best.subsetIndices, originalIndexMap)
+	indices := best.subsetIndices
+	if best != initial {
+		indices = mapIndices(best.subsetIndices, originalIndexMap)
+	}
 
 	return subsetsEval{
 		SubsetsIndices: indices,
 		ExactlyCovered: true,
 		Cost:           best.objectiveValue,
-		Optimal:        true,
-	}, nil
+		Optimal:        stats.LowerBound >= best.objectiveValue,
+	}, stats, nil
+}
+
+// checkInitialSolution checks that the subset indices are an exact cover of
+// the instance and returns its cost. what names the indices in the errors.
+func checkInitialSolution(ins instance, what string, indices []int) (float64, error) {
+	v := cover.Verify(cover.Instance{ElementCount: ins.m, Subsets: ins.subsets, Costs: ins.costs}, indices)
+	if len(v.InvalidIndices) > 0 {
+		return 0, fmt.Errorf("%s: invalid subset indices %v", what, v.InvalidIndices)
+	}
+	if len(v.DuplicateIndices) > 0 {
+		return 0, fmt.Errorf("%s: repeated subsets %v", what, v.DuplicateIndices)
+	}
+	if len(v.OverCovered) > 0 {
+		return 0, fmt.Errorf("%s: not an exact cover, over-covered elements %v", what, v.OverCovered)
+	}
+	if len(v.UnderCovered) > 0 {
+		return 0, fmt.Errorf("%s: not an exact cover, uncovered elements %v", what, v.UnderCovered)
+	}
+	return v.Cost, nil
 }
 
 func mapIndices(indices []int, indexMap []int) []int {
@@ -287,7 +607,9 @@ type BranchIndices struct {
 	j uint32
 }
 
-// symmetricDifference calculates the set symmetric difference of x and y.
+// symmetricDifference calculates the set symmetric difference of x and y. It
+// is sorted so the branching, and thus the search, is deterministic, e.g.
+// when resuming from a checkpoint.
 func symmetricDifference(x, y []int) []int {
 	xSet := make(map[int]struct{}, len(x))
 	for _, e := range x {
@@ -314,6 +636,7 @@ func symmetricDifference(x, y []int) []int {
 	for k := range diffSet {
 		diff = append(diff, k)
 	}
+	slices.Sort(diff)
 	return diff
 }
 
@@ -25,7 +25,9 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/snow-abstraction/cover"
@@ -121,6 +123,139 @@ func TestCreateSubInstancesTricker(t *testing.T) {
 	assert.DeepEqual(t, expectedDiff01Diff12NodeIns, actualDiff01Diff12NodeIns.ins, cmp.AllowUnexported(instance{}))
 }
 
+func TestCreateChildSubInstanceMatchesCreateSubInstance(t *testing.T) {
+	ins, err := makeInstanceFromCover(cover.MakeRandomInstance(20, 600, 1, 3))
+	assert.NilError(t, err)
+	ins, _ = removeMoreExpensiveDuplicates(ins)
+
+	// Branch down the "both" and "diff" sides of the tree comparing the
+	// incremental sub-instances with those made from all subsets.
+	for _, both := range []bool{true, false} {
+		node := tree.CreateRoot()
+		sub, err := createSubInstance(ins, node)
+		assert.NilError(t, err)
+		for sub != nil && !sub.isSolution {
+			b, err := findBranchingElements(sub.ins)
+			assert.NilError(t, err)
+			bothNode, diffNode := node.Branch(0, b.i, b.j)
+			node = diffNode
+			if both {
+				node = bothNode
+			}
+
+			child, err := createChildSubInstance(ins, node, candidatesOf(sub))
+			assert.NilError(t, err)
+			expected, err := createSubInstance(ins, node)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, child, expected, cmp.AllowUnexported(subInstance{}, instance{}))
+			sub = child
+		}
+	}
+}
+
+func TestCreateChildSubInstanceRejectsRepeatedBranching(t *testing.T) {
+	ins, err := MakeInstance(3, [][]int{{0, 1}, {0}, {1}, {2}}, []float64{1, 2, 3, 4})
+	assert.NilError(t, err)
+	bothNode, _ := tree.CreateRoot().Branch(0, 0, 1)
+	sub, err := createChildSubInstance(ins, bothNode, []int32{0, 1, 2, 3})
+	assert.NilError(t, err)
+
+	again, _ := bothNode.Branch(0, 0, 1)
+	_, err = createChildSubInstance(ins, again, candidatesOf(sub))
+	assert.ErrorContains(t, err, "already branched on")
+}
+
+func TestBBWithoutCandidates(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	sol, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+
+	defer func(saved int) { maxHeldCandidates = saved }(maxHeldCandidates)
+	maxHeldCandidates = 0
+	withoutSol, withoutStats, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, withoutSol, sol)
+	assert.Equal(t, withoutStats.Nodes, stats.Nodes)
+}
+
+// deepNode branches down the "diff" side of the tree of a larger random
+// instance to the depth and returns the node and its candidates.
+func deepNode(b *testing.B, depth int) (instance, tree.Node, []int32) {
+	ins, err := makeInstanceFromCover(cover.MakeRandomInstance(40, 2000, 1, 1))
+	assert.NilError(b, err)
+	ins, _ = removeMoreExpensiveDuplicates(ins)
+
+	node := tree.CreateRoot()
+	sub, err := createSubInstance(ins, node)
+	assert.NilError(b, err)
+	var candidates []int32
+	for d := 0; d < depth; d++ {
+		assert.Assert(b, sub != nil && !sub.isSolution, "depth %d", d)
+		branch, err := findBranchingElements(sub.ins)
+		assert.NilError(b, err)
+		_, node = node.Branch(0, branch.i, branch.j)
+		candidates = candidatesOf(sub)
+		sub, err = createChildSubInstance(ins, node, candidates)
+		assert.NilError(b, err)
+	}
+	return ins, node, candidates
+}
+
+// The benchmarks of making a deep node's sub-instance also report the memory
+// that the node's candidates take until both it and its sibling are popped.
+// Without candidates, a node only takes its 13 bytes in the tree.
+func BenchmarkCreateSubInstance(b *testing.B) {
+	ins, node, _ := deepNode(b, 10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := createSubInstance(ins, node)
+		assert.NilError(b, err)
+	}
+}
+
+func BenchmarkCreateChildSubInstance(b *testing.B) {
+	ins, node, candidates := deepNode(b, 10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := createChildSubInstance(ins, node, candidates)
+		assert.NilError(b, err)
+	}
+	b.ReportMetric(float64(4*len(candidates)), "candidate-bytes/node")
+}
+
+// BenchmarkBBPeakMemory reports the peak heap during a solve with and
+// without candidates, see maxHeldCandidates. The heap is sampled at every
+// node so the time is not comparable with the other benchmarks.
+func BenchmarkBBPeakMemory(b *testing.B) {
+	ins := cover.MakeRandomInstance(30, 3000, 1, 7)
+	for _, held := range []int{maxHeldCandidates, 0} {
+		name := "candidates"
+		if held == 0 {
+			name = "no candidates"
+		}
+		b.Run(name, func(b *testing.B) {
+			defer func(saved int) { maxHeldCandidates = saved }(maxHeldCandidates)
+			maxHeldCandidates = held
+
+			var peak uint64
+			var m runtime.MemStats
+			for i := 0; i < b.N; i++ {
+				runtime.GC()
+				runtime.ReadMemStats(&m)
+				base := m.HeapAlloc
+				_, _, err := SolveByBranchAndBoundWithOptions(ins, Options{OnNode: func(tree.Node) {
+					runtime.ReadMemStats(&m)
+					peak = max(peak, m.HeapAlloc-min(base, m.HeapAlloc))
+				}})
+				assert.NilError(b, err)
+			}
+			b.ReportMetric(float64(peak), "peak-heap-bytes")
+		})
+	}
+}
+
 func testBBFindsEquallyGoodSolution(t *testing.T, spec cover.TestInstanceSpecification) {
 	pythonResultBytes, err := os.ReadFile(filepath.Join("../..", spec.PythonSolutionPath))
 	assert.NilError(t, err)
@@ -183,6 +318,27 @@ func TestBBOnSmallInstances(t *testing.T) {
 	}
 }
 
+func TestBBAgreesWithVerify(t *testing.T) {
+	t.Parallel()
+	for _, spec := range loadTinyInstanceSpecifications(t) {
+		solverInstance := loadSolverInstance(t, filepath.Join("../..", spec.InstancePath))
+		result, err := SolveByBranchAndBoundInternal(solverInstance)
+		assert.NilError(t, err)
+		if !result.ExactlyCovered {
+			continue
+		}
+
+		// The result agrees with an independent verification of it.
+		v := cover.Verify(cover.Instance{
+			ElementCount: solverInstance.m,
+			Subsets:      solverInstance.subsets,
+			Costs:        solverInstance.costs,
+		}, result.SubsetsIndices)
+		assert.Assert(t, v.ExactlyCovered, "%+v", spec)
+		assert.Assert(t, v.CostMatches(result.Cost), "%v != %v", v.Cost, result.Cost)
+	}
+}
+
 func BenchmarkBBOnRandomTinyInstances(b *testing.B) {
 	instanceSpecifications := loadTinyInstanceSpecifications(b)
 
@@ -260,3 +416,136 @@ func BenchmarkBBOnRandomSmallInstances(b *testing.B) {
 		}
 	}
 }
+
+func TestBBNodeLimit(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	optimal, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+	assert.Assert(t, optimal.Optimal)
+	assert.Assert(t, !stats.LimitReached)
+	assert.Equal(t, stats.LowerBound, optimal.Cost)
+	assert.Assert(t, stats.Nodes > 2, "the instance should need branching")
+
+	limited, limitedStats, err := SolveByBranchAndBoundWithOptions(ins, Options{NodeLimit: 2})
+	assert.NilError(t, err)
+	assert.Assert(t, limitedStats.LimitReached)
+	assert.Equal(t, limitedStats.Nodes, 2)
+	assert.Assert(t, !limited.Optimal)
+	assert.Assert(t, limitedStats.LowerBound <= optimal.Cost+1e-9)
+	if limited.ExactlyCovered {
+		assert.Assert(t, limited.Cost >= optimal.Cost-1e-9)
+	}
+}
+
+func TestBBInfeasibleStats(t *testing.T) {
+	ins := cover.Instance{ElementCount: 3, Subsets: [][]int{{0, 1}, {1, 2}}, Costs: []float64{1, 1}}
+	sol, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+	assert.Assert(t, !sol.ExactlyCovered)
+	assert.Assert(t, !stats.LimitReached)
+	assert.Assert(t, math.IsInf(stats.LowerBound, 1))
+}
+
+func TestBBOnNode(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	var nodes []tree.Node
+	_, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{OnNode: func(node tree.Node) {
+		nodes = append(nodes, node)
+	}})
+	assert.NilError(t, err)
+	assert.Assert(t, len(nodes) >= stats.Nodes)
+	for _, node := range nodes {
+		assert.Assert(t, node.Outcome() != tree.Open)
+	}
+
+	nodes = nil
+	_, _, err = SolveByBranchAndBoundWithOptions(ins, Options{NodeLimit: 2, OnNode: func(node tree.Node) {
+		nodes = append(nodes, node)
+	}})
+	assert.NilError(t, err)
+	open := 0
+	for _, node := range nodes {
+		if node.Outcome() == tree.Open {
+			open++
+		}
+	}
+	assert.Assert(t, open > 0)
+}
+
+func TestBBOnProgress(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	var progress []Progress
+	sol, _, err := SolveByBranchAndBoundWithOptions(ins, Options{
+		OnProgress:       func(p Progress) { progress = append(progress, p) },
+		ProgressInterval: time.Nanosecond,
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, len(progress) > 0)
+
+	incumbent := math.Inf(1)
+	for _, p := range progress {
+		assert.Assert(t, p.Bound <= sol.Cost+1e-9)
+		if p.NewIncumbent {
+			assert.Assert(t, p.Incumbent < incumbent)
+			assert.Assert(t, p.Gap >= 0)
+		}
+		incumbent = p.Incumbent
+	}
+	assert.Equal(t, incumbent, sol.Cost)
+}
+
+func TestBBInitialSolution(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	optimal, _, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+
+	var improvements []float64
+	sol, _, err := SolveByBranchAndBoundWithOptions(ins, Options{
+		InitialSolution: optimal.SubsetsIndices,
+		OnIncumbent: func(subsetIndices []int, cost float64) {
+			assert.Assert(t, cover.Verify(ins, subsetIndices).CostMatches(cost))
+			improvements = append(improvements, cost)
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, sol.Optimal)
+	assert.Equal(t, sol.Cost, optimal.Cost)
+	assert.DeepEqual(t, sol.SubsetsIndices, optimal.SubsetsIndices)
+	// An optimal initial solution can not be improved on.
+	assert.Equal(t, len(improvements), 0)
+
+	_, _, err = SolveByBranchAndBoundWithOptions(ins, Options{
+		OnIncumbent: func(subsetIndices []int, cost float64) {
+			assert.Assert(t, cover.Verify(ins, subsetIndices).CostMatches(cost))
+			improvements = append(improvements, cost)
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, len(improvements) > 0)
+	assert.Equal(t, improvements[len(improvements)-1], optimal.Cost)
+
+	_, _, err = SolveByBranchAndBoundWithOptions(ins, Options{InitialSolution: []int{0}})
+	assert.ErrorContains(t, err, "initial solution: not an exact cover")
+}
+
+func TestBBStats(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	sol, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+
+	// Every node except the root is the child of a branched node.
+	fathomed := stats.PrunedNodes + stats.InfeasibleNodes + stats.IntegralNodes + stats.BranchedNodes
+	assert.Equal(t, fathomed, 2*stats.BranchedNodes+1)
+	assert.Assert(t, stats.DualRuns <= stats.Nodes)
+	assert.Assert(t, stats.MeanSubgradientIterations() > 0)
+	assert.Assert(t, stats.MaxOpenNodes >= 2)
+	assert.Assert(t, stats.RootBound > 0 && stats.RootBound <= sol.Cost+1e-9)
+	assert.Assert(t, stats.DualTime > 0)
+
+	var combined Stats
+	combined.AddComponent(stats)
+	combined.AddComponent(stats)
+	assert.Equal(t, combined.Nodes, 2*stats.Nodes)
+	assert.Equal(t, combined.MaxOpenNodes, stats.MaxOpenNodes)
+	assert.Equal(t, combined.RootBound, 2*stats.RootBound)
+}
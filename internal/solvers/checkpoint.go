@@ -0,0 +1,150 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/internal/tree"
+)
+
+// ErrCheckpointMismatch is returned when resuming from a checkpoint of
+// another instance.
+var ErrCheckpointMismatch = errors.New("the checkpoint is of another instance")
+
+// Checkpoint is the state of a branch-and-bound search, see
+// Options.OnCheckpoint. Resuming from it, see Options.Resume, continues the
+// search as if it had not been interrupted.
+type Checkpoint struct {
+	// The hex encoded SHA-256 hash of the instance, see InstanceHash.
+	InstanceHash string `json:"instanceHash"`
+	// The best exact cover found, if any.
+	Incumbent *CheckpointSolution `json:"incumbent,omitempty"`
+	// The number of nodes processed.
+	Nodes int `json:"nodes"`
+	// The nodes waiting to be processed, in the queue's order.
+	Open []CheckpointNode `json:"open"`
+}
+
+// CheckpointSolution is an exact cover in a checkpoint.
+type CheckpointSolution struct {
+	Cost    float64 `json:"cost"`
+	Subsets []int   `json:"subsets"`
+}
+
+// CheckpointNode is an open node given by its path of branches from the
+// root. The root itself has an empty path.
+type CheckpointNode struct {
+	Path []CheckpointBranch `json:"path"`
+}
+
+// CheckpointBranch is a node on the path to an open node.
+type CheckpointBranch struct {
+	// both or diff
+	Kind       string  `json:"kind"`
+	I          uint32  `json:"i"`
+	J          uint32  `json:"j"`
+	LowerBound float64 `json:"lowerBound"`
+}
+
+// InstanceHash is the hex encoded SHA-256 hash of the binary encoding, see
+// cover.WriteBinary, of the instance without its names. It identifies the
+// instance of a checkpoint.
+func InstanceHash(ins cover.Instance) (string, error) {
+	var b bytes.Buffer
+	err := cover.WriteBinary(&b, cover.Instance{ElementCount: ins.ElementCount, Subsets: ins.Subsets, Costs: ins.Costs})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(b.Bytes())
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// WriteCheckpoint writes the checkpoint as JSON.
+func WriteCheckpoint(w io.Writer, c Checkpoint) error {
+	enc := json.NewEncoder(w)
+	return enc.Encode(c)
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint.
+func ReadCheckpoint(r io.Reader) (*Checkpoint, error) {
+	var c Checkpoint
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("checkpoint: %w", err)
+	}
+	return &c, nil
+}
+
+// makeCheckpointNode makes the path of branches to the node.
+func makeCheckpointNode(node tree.Node) CheckpointNode {
+	var path []CheckpointBranch
+	for n := node; n.Kind() != tree.Root; n = n.Parent() {
+		kind := "both"
+		if n.Kind() == tree.DiffBranch {
+			kind = "diff"
+		}
+		path = append(path, CheckpointBranch{kind, n.I(), n.J(), n.LowerBound()})
+	}
+	slices.Reverse(path)
+	return CheckpointNode{Path: path}
+}
+
+// checkpointNodeKey identifies a child of a node when restoring the nodes.
+type checkpointNodeKey struct {
+	parent tree.Node
+	branch CheckpointBranch
+}
+
+// restoreNodes makes the open nodes of the checkpoint. Nodes with common
+// ancestors share them and the ancestors' outcome is tree.Branched.
+func restoreNodes(open []CheckpointNode) ([]tree.Node, error) {
+	root := tree.CreateRoot()
+	children := make(map[checkpointNodeKey]tree.Node)
+	nodes := make([]tree.Node, 0, len(open))
+	for _, c := range open {
+		node := root
+		for _, branch := range c.Path {
+			key := checkpointNodeKey{node, branch}
+			child, found := children[key]
+			if !found {
+				both, diff := node.Branch(branch.LowerBound, branch.I, branch.J)
+				switch branch.Kind {
+				case "both":
+					child = both
+				case "diff":
+					child = diff
+				default:
+					return nil, fmt.Errorf("checkpoint: unknown branch kind %q", branch.Kind)
+				}
+				children[key] = child
+			}
+			node.SetOutcome(tree.Branched)
+			node = child
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
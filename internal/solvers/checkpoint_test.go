@@ -0,0 +1,77 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	full, fullStats, err := SolveByBranchAndBoundWithOptions(ins, Options{})
+	assert.NilError(t, err)
+
+	var checkpoints []Checkpoint
+	_, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{
+		NodeLimit: 3,
+		OnCheckpoint: func(c Checkpoint) error {
+			checkpoints = append(checkpoints, c)
+			return nil
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, stats.LimitReached)
+	assert.Equal(t, len(checkpoints), 1)
+	assert.Equal(t, checkpoints[0].Nodes, 3)
+	assert.Assert(t, len(checkpoints[0].Open) > 0)
+
+	var b bytes.Buffer
+	assert.NilError(t, WriteCheckpoint(&b, checkpoints[0]))
+	c, err := ReadCheckpoint(&b)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *c, checkpoints[0])
+
+	resumed, resumedStats, err := SolveByBranchAndBoundWithOptions(ins, Options{Resume: c})
+	assert.NilError(t, err)
+	assert.Assert(t, resumed.Optimal)
+	assert.Equal(t, resumed.Cost, full.Cost)
+	assert.DeepEqual(t, resumed.SubsetsIndices, full.SubsetsIndices)
+	// The resumed search processes the same nodes as the full search.
+	assert.Equal(t, resumedStats.Nodes, fullStats.Nodes)
+
+	// The incumbent is checked since the instance hash does not cover it.
+	resume := *c
+	resume.Incumbent = &CheckpointSolution{Cost: 0.5, Subsets: []int{9999}}
+	_, _, err = SolveByBranchAndBoundWithOptions(ins, Options{Resume: &resume})
+	assert.ErrorContains(t, err, "checkpoint incumbent: invalid subset indices [9999]")
+	resume.Incumbent = &CheckpointSolution{Cost: 0.5, Subsets: full.SubsetsIndices}
+	_, _, err = SolveByBranchAndBoundWithOptions(ins, Options{Resume: &resume})
+	assert.ErrorContains(t, err, "checkpoint incumbent: the cost is 0.5")
+	resume.Incumbent = &CheckpointSolution{Cost: full.Cost, Subsets: full.SubsetsIndices}
+	withIncumbent, _, err := SolveByBranchAndBoundWithOptions(ins, Options{Resume: &resume})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, withIncumbent.SubsetsIndices, full.SubsetsIndices)
+
+	other := cover.MakeRandomInstance(20, 600, 2, 3)
+	_, _, err = SolveByBranchAndBoundWithOptions(other, Options{Resume: c})
+	assert.ErrorIs(t, err, ErrCheckpointMismatch)
+}
@@ -18,9 +18,6 @@
 package solvers
 
 import (
-	"errors"
-	"fmt"
-
 	"github.com/snow-abstraction/cover"
 )
 
@@ -42,57 +39,19 @@ type instance struct {
 type subsetsEval cover.SubsetsEval
 
 // Make an Instance and check the constraints that an Instance should satisfy.
+// See cover.Validate for the errors returned.
 func MakeInstance(m int, subsets [][]int, costs []float64) (instance, error) {
-	if m < 0 {
-		return instance{}, fmt.Errorf(
-			"the number of elements n must be nonnegative. %d was supplied", m)
-	} else if m == 0 {
-		if len(subsets) != 0 && len(costs) != 0 {
-			return instance{}, errors.New(
-				"when the set is empty (n=0), then both subsets and costs must be empty")
-		}
-		return instance{m: 0, subsets: [][]int{}, costs: []float64{}}, nil
-
-	}
-
-	for i, subset := range subsets {
-		if len(subset) == 0 {
-			return instance{}, fmt.Errorf(
-				"subset index %d was empty. Empty subsets are not allowed", i)
-		}
-
-		for _, element := range subset {
-			if element < 0 || element >= m {
-				return instance{}, fmt.Errorf(
-					"the subset %v with index %d is invalid since it contains element %d which is not a member of [0, %d)",
-					subset, i, element, m)
-			}
-		}
-
-		prevElement := subset[0]
-		for _, element := range subset[1:] {
-			if prevElement >= element {
-				return instance{}, fmt.Errorf(
-					"the subset %v with index %d is invalid since it is not sorted or contains duplicate elements",
-					subset, i)
-
-			}
-			prevElement = element
-		}
-	}
-
-	if len(subsets) != len(costs) {
-		return instance{}, errors.New("there must be exactly one cost per subset")
+	if err := cover.Validate(cover.Instance{ElementCount: m, Subsets: subsets, Costs: costs}); err != nil {
+		return instance{}, err
 	}
+	return makeValidatedInstance(m, subsets, costs), nil
+}
 
-	for i, cost := range costs {
-		if cost <= 0 {
-			return instance{}, fmt.Errorf(
-				"the cost %f with index %d is invalid since it is only (strictly) positive costs are supported",
-				cost, i)
-
-		}
+// makeValidatedInstance makes an instance from data that has already passed
+// cover.Validate.
+func makeValidatedInstance(m int, subsets [][]int, costs []float64) instance {
+	if m == 0 {
+		return instance{m: 0, subsets: [][]int{}, costs: []float64{}}
 	}
-
-	return instance{m: m, subsets: subsets, costs: costs}, nil
+	return instance{m: m, subsets: subsets, costs: costs}
 }
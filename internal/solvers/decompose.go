@@ -0,0 +1,111 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/snow-abstraction/cover"
+)
+
+// Solver is the signature of the exported solvers, e.g. SolveByBranchAndBound.
+type Solver func(cover.Instance) (cover.SubsetsEval, error)
+
+type componentResult struct {
+	index int
+	sol   cover.SubsetsEval
+	err   error
+}
+
+// SolveByComponents splits the instance into its connected components (see
+// cover.Components), solves each of them using solve and combines the
+// results. The components are solved by workers goroutines concurrently. If
+// workers <= 1, the components are solved one by one.
+//
+// The result is an exact cover only if every component has one and it is
+// optimal only if all the components' results are optimal. Since solve is
+// called once per component, limits of solve, e.g. Options.NodeLimit and
+// Options.TimeLimit, apply to each component separately.
+func SolveByComponents(ins cover.Instance, solve Solver, workers int) (cover.SubsetsEval, error) {
+	if err := cover.Validate(ins); err != nil {
+		return cover.SubsetsEval{}, err
+	}
+
+	components := cover.Components(ins)
+	slog.Debug("solving by components", "count", len(components))
+	for _, c := range components {
+		if len(c.Subsets) == 0 {
+			slog.Debug("element in no subset", "element", c.Elements[0])
+			return cover.SubsetsEval{}, nil
+		}
+	}
+
+	results := make([]componentResult, len(components))
+	solveComponent := func(k int) componentResult {
+		sol, err := solve(ins.SubInstance(components[k]))
+		return componentResult{k, sol, err}
+	}
+
+	if workers <= 1 {
+		for k := range components {
+			results[k] = solveComponent(k)
+		}
+	} else {
+		jobs := make(chan int, len(components))
+		done := make(chan componentResult, len(components))
+		for w := 0; w < workers; w++ {
+			go func() {
+				for k := range jobs {
+					done <- solveComponent(k)
+				}
+			}()
+		}
+		for k := range components {
+			jobs <- k
+		}
+		close(jobs)
+		for range components {
+			r := <-done
+			results[r.index] = r
+		}
+	}
+
+	combined := cover.SubsetsEval{
+		SubsetsIndices: make([]int, 0),
+		ExactlyCovered: true,
+		Optimal:        true,
+	}
+	for k, r := range results {
+		if r.err != nil {
+			return cover.SubsetsEval{}, r.err
+		}
+		if !r.sol.ExactlyCovered {
+			return cover.SubsetsEval{}, nil
+		}
+		for _, j := range r.sol.SubsetsIndices {
+			combined.SubsetsIndices = append(combined.SubsetsIndices, components[k].Subsets[j])
+		}
+		combined.Cost += r.sol.Cost
+		combined.Optimal = combined.Optimal && r.sol.Optimal
+	}
+	slices.Sort(combined.SubsetsIndices)
+	combined.SubsetNames = ins.SubsetNamesOf(combined.SubsetsIndices)
+
+	return combined, nil
+}
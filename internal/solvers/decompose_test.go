@@ -0,0 +1,90 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"gotest.tools/v3/assert"
+)
+
+// blockDiagonalInstance places copies of ins after each other so no subset
+// contains elements from two copies.
+func blockDiagonalInstance(ins cover.Instance, copies int) cover.Instance {
+	result := cover.Instance{ElementCount: ins.ElementCount * copies}
+	for k := 0; k < copies; k++ {
+		for j, subset := range ins.Subsets {
+			shifted := make([]int, 0, len(subset))
+			for _, e := range subset {
+				shifted = append(shifted, e+k*ins.ElementCount)
+			}
+			result.Subsets = append(result.Subsets, shifted)
+			result.Costs = append(result.Costs, ins.Costs[j])
+		}
+	}
+	return result
+}
+
+func TestSolveByComponentsBlockDiagonal(t *testing.T) {
+	block := cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1, 2}, {0}, {1}, {1, 2}, {0, 2}},
+		Costs:        []float64{17, 5, 4, 3, 3},
+	}
+	ins := blockDiagonalInstance(block, 3)
+
+	for _, workers := range []int{1, 4} {
+		result, err := SolveByComponents(ins, SolveByBranchAndBound, workers)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, result, cover.SubsetsEval{
+			SubsetsIndices: []int{2, 4, 7, 9, 12, 14},
+			ExactlyCovered: true,
+			Cost:           21,
+			Optimal:        true,
+		})
+	}
+}
+
+func TestSolveByComponentsUncoveredElement(t *testing.T) {
+	ins := cover.Instance{ElementCount: 2, Subsets: [][]int{{0}}, Costs: []float64{1}}
+	result, err := SolveByComponents(ins, SolveByBranchAndBound, 1)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, cover.SubsetsEval{})
+}
+
+func TestSolveByComponentsOnTinyInstances(t *testing.T) {
+	for _, spec := range loadTinyInstanceSpecifications(t) {
+		t.Run(fmt.Sprintf("instance %+v", spec), func(t *testing.T) {
+			t.Parallel()
+			ins, err := cover.ReadJsonInstance(filepath.Join("../..", spec.InstancePath))
+			assert.NilError(t, err)
+			ins2 := blockDiagonalInstance(*ins, 2)
+
+			expected, err := SolveByBruteForce(*ins)
+			assert.NilError(t, err)
+			actual, err := SolveByComponents(ins2, SolveByBruteForce, 2)
+			assert.NilError(t, err)
+			assert.Equal(t, expected.ExactlyCovered, actual.ExactlyCovered)
+			assert.Assert(t, math.Abs(2*expected.Cost-actual.Cost) < 1e-9)
+		})
+	}
+}
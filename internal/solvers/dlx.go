@@ -0,0 +1,328 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// A Dancing Links solver for the "Weighted Exact Cover Problem".
+package solvers
+
+import (
+	"math"
+	"slices"
+)
+
+// DancingLinksOptions are options for SolveByDancingLinksWithOptions.
+type DancingLinksOptions struct {
+	// If true, the Lagrangian dual bound of the remaining elements, see
+	// runDualIterations, is also used for pruning. It is stronger than the
+	// default bound but expensive to calculate at each node.
+	LagrangianBound bool
+}
+
+// dlx is the Dancing Links representation of an instance for Knuth's
+// Algorithm X. Node 0 is the root header, nodes 1, ..., m are the column
+// headers of the elements and the other nodes are the elements of the
+// subsets, one circular list per subset.
+type dlx struct {
+	left, right, up, down []int
+	// column header of each node
+	column []int
+	// subset index of each node, -1 for headers
+	row []int
+	// number of nodes in each column, indexed by the header
+	size []int
+
+	costs []float64
+	// The minimum cost share of each element, indexed by the header, where
+	// the share of an element of a subset is the subset's cost divided by
+	// its size. The sum of the shares of the uncovered elements is a lower
+	// bound on the cost of covering them.
+	share []float64
+}
+
+// newDLX makes the links of the instance. The subsets are linked in the
+// given order which is the order they are tried.
+func newDLX(ins instance, order []int) *dlx {
+	m := ins.m
+	d := &dlx{costs: ins.costs, share: make([]float64, m+1)}
+	add := func(l, r, u, dn, col, row int) {
+		d.left = append(d.left, l)
+		d.right = append(d.right, r)
+		d.up = append(d.up, u)
+		d.down = append(d.down, dn)
+		d.column = append(d.column, col)
+		d.row = append(d.row, row)
+	}
+	for c := 0; c <= m; c++ {
+		add((c+m)%(m+1), (c+1)%(m+1), c, c, c, -1)
+		d.share[c] = math.Inf(1)
+	}
+	d.size = make([]int, m+1)
+
+	for _, j := range order {
+		subset := ins.subsets[j]
+		share := ins.costs[j] / float64(len(subset))
+		first := len(d.row)
+		for k, e := range subset {
+			c := e + 1
+			n := len(d.row)
+			// link into the row's circular list
+			l, r := n-1, first
+			if k == 0 {
+				l = n
+			}
+			add(l, r, d.up[c], c, c, j)
+			d.right[l] = n
+			d.left[first] = n
+			// link at the bottom of the column
+			d.down[d.up[c]] = n
+			d.up[c] = n
+			d.size[c]++
+			d.share[c] = min(d.share[c], share)
+		}
+	}
+	return d
+}
+
+func (d *dlx) cover(c int) {
+	d.right[d.left[c]] = d.right[c]
+	d.left[d.right[c]] = d.left[c]
+	for i := d.down[c]; i != c; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.column[j]]--
+		}
+	}
+}
+
+func (d *dlx) uncover(c int) {
+	for i := d.up[c]; i != c; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.column[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[c]] = c
+	d.left[d.right[c]] = c
+}
+
+// chooseColumn chooses the uncovered column with the fewest subsets, the
+// MRV (minimum remaining values) heuristic. It returns 0 if all columns are
+// covered.
+func (d *dlx) chooseColumn() int {
+	best := 0
+	for c := d.right[0]; c != 0; c = d.right[c] {
+		if best == 0 || d.size[c] < d.size[best] {
+			best = c
+			if d.size[c] <= 1 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// coverRow covers the columns of the row of node r except r's own column,
+// which is covered by the caller, and returns the sum of their shares
+// including r's.
+func (d *dlx) coverRow(r int) float64 {
+	share := d.share[d.column[r]]
+	for j := d.right[r]; j != r; j = d.right[j] {
+		d.cover(d.column[j])
+		share += d.share[d.column[j]]
+	}
+	return share
+}
+
+func (d *dlx) uncoverRow(r int) {
+	for j := d.left[r]; j != r; j = d.left[j] {
+		d.uncover(d.column[j])
+	}
+}
+
+// remaining returns the instance of the uncovered elements and the subsets
+// still linked, with the elements renumbered.
+func (d *dlx) remaining() instance {
+	newIndex := make(map[int]int)
+	for c := d.right[0]; c != 0; c = d.right[c] {
+		newIndex[c] = len(newIndex)
+	}
+	seen := make(map[int]bool)
+	var ins instance
+	ins.m = len(newIndex)
+	for c := d.right[0]; c != 0; c = d.right[c] {
+		for i := d.down[c]; i != c; i = d.down[i] {
+			if seen[d.row[i]] {
+				continue
+			}
+			seen[d.row[i]] = true
+			subset := []int{newIndex[c]}
+			for j := d.right[i]; j != i; j = d.right[j] {
+				subset = append(subset, newIndex[d.column[j]])
+			}
+			slices.Sort(subset)
+			ins.subsets = append(ins.subsets, subset)
+			ins.costs = append(ins.costs, d.costs[d.row[i]])
+		}
+	}
+	return ins
+}
+
+// dlxSearch is the state of a minimum cost search.
+type dlxSearch struct {
+	d       *dlx
+	opts    DancingLinksOptions
+	partial []int
+	best    subsetsEval
+}
+
+// search extends the partial cover, of cost cost, where remainingShare is
+// the sum of the shares of the uncovered elements.
+func (s *dlxSearch) search(cost float64, remainingShare float64) error {
+	d := s.d
+	if d.right[0] == 0 {
+		if !s.best.ExactlyCovered || cost < s.best.Cost {
+			s.best = subsetsEval{SubsetsIndices: slices.Clone(s.partial), ExactlyCovered: true, Cost: cost}
+		}
+		return nil
+	}
+
+	// The shares are summed in different orders so allow for rounding.
+	if s.best.ExactlyCovered && cost+remainingShare*(1-1e-9) >= s.best.Cost {
+		return nil
+	}
+
+	c := d.chooseColumn()
+	if d.size[c] == 0 {
+		return nil
+	}
+	if s.best.ExactlyCovered && s.opts.LagrangianBound {
+		// The dual bound is for set covering the remaining elements and so
+		// also for exactly covering them.
+		remaining := d.remaining()
+		matrix, err := convertSubsetsToMatrix(remaining.subsets)
+		if err != nil {
+			return err
+		}
+		dual, err := runDualIterations(matrix, remaining.costs)
+		if err != nil {
+			return err
+		}
+		if cost+dual.dualObjectiveValue >= s.best.Cost {
+			return nil
+		}
+	}
+	d.cover(c)
+	for r := d.down[c]; r != c; r = d.down[r] {
+		s.partial = append(s.partial, d.row[r])
+		share := d.coverRow(r)
+		err := s.search(cost+d.costs[d.row[r]], remainingShare-share)
+		d.uncoverRow(r)
+		s.partial = s.partial[:len(s.partial)-1]
+		if err != nil {
+			d.uncover(c)
+			return err
+		}
+	}
+	d.uncover(c)
+	return nil
+}
+
+// SolveByDancingLinksInternal finds a minimum cost exact cover using Knuth's
+// Algorithm X with Dancing Links and the MRV heuristic. Partial covers are
+// pruned if their cost plus a lower bound on the cost of covering the
+// remaining elements is not less than the cost of the best cover found. The
+// subsets are tried in order of increasing cost.
+//
+// If a minimum cost exact cover exists, the returned subsetsEval will contain
+// indices to this cover and its exactlyCovered flag will be true. Otherwise,
+// the zero value of subsetEval will be returned.
+func SolveByDancingLinksInternal(ins instance, opts DancingLinksOptions) (subsetsEval, error) {
+	order := make([]int, len(ins.subsets))
+	for j := range order {
+		order[j] = j
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmpFloat(ins.costs[a], ins.costs[b])
+	})
+
+	d := newDLX(ins, order)
+	remainingShare := 0.0
+	for c := 1; c <= ins.m; c++ {
+		remainingShare += d.share[c]
+	}
+	s := dlxSearch{d: d, opts: opts}
+	if err := s.search(0, remainingShare); err != nil {
+		return subsetsEval{}, err
+	}
+	if !s.best.ExactlyCovered {
+		return subsetsEval{}, nil
+	}
+	s.best.Optimal = true
+	return s.best, nil
+}
+
+func cmpFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// FindExactCoversInternal finds up to k exact covers, or all if k <= 0,
+// ignoring the costs using Knuth's Algorithm X with Dancing Links and the
+// MRV heuristic. Each cover is the subset indices in the order they were
+// chosen.
+func FindExactCoversInternal(ins instance, k int) [][]int {
+	order := make([]int, len(ins.subsets))
+	for j := range order {
+		order[j] = j
+	}
+	d := newDLX(ins, order)
+
+	var covers [][]int
+	var partial []int
+	var search func() bool // returns false to stop
+	search = func() bool {
+		if d.right[0] == 0 {
+			covers = append(covers, slices.Clone(partial))
+			return k <= 0 || len(covers) < k
+		}
+		c := d.chooseColumn()
+		if d.size[c] == 0 {
+			return true
+		}
+		d.cover(c)
+		defer d.uncover(c)
+		for r := d.down[c]; r != c; r = d.down[r] {
+			partial = append(partial, d.row[r])
+			d.coverRow(r)
+			more := search()
+			d.uncoverRow(r)
+			partial = partial[:len(partial)-1]
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	search()
+	return covers
+}
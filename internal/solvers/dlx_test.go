@@ -0,0 +1,109 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"gotest.tools/v3/assert"
+)
+
+func TestDLXCheaperSolutionFound(t *testing.T) {
+	ins, err := MakeInstance(3, [][]int{{0, 1, 2}, {0}, {1}, {1, 2}, {0, 2}}, []float64{17, 5, 4, 3, 3})
+	assert.NilError(t, err)
+	result, err := SolveByDancingLinksInternal(ins, DancingLinksOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, subsetsEval{SubsetsIndices: []int{4, 2}, ExactlyCovered: true, Cost: 7, Optimal: true})
+}
+
+func TestDLXInfeasibleAndEmpty(t *testing.T) {
+	ins, err := MakeInstance(3, [][]int{{0, 1}, {1, 2}, {0, 2}}, []float64{1, 1, 1})
+	assert.NilError(t, err)
+	result, err := SolveByDancingLinksInternal(ins, DancingLinksOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, subsetsEval{})
+
+	ins, err = MakeInstance(0, [][]int{}, []float64{})
+	assert.NilError(t, err)
+	result, err = SolveByDancingLinksInternal(ins, DancingLinksOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, subsetsEval{ExactlyCovered: true, Optimal: true})
+}
+
+func TestDLXOnTinyInstances(t *testing.T) {
+	t.Parallel()
+	for _, spec := range loadTinyInstanceSpecifications(t) {
+		spec := spec
+		t.Run(fmt.Sprintf("instance %+v", spec), func(t *testing.T) {
+			t.Parallel()
+			ins, err := cover.ReadJsonInstance(filepath.Join("../..", spec.InstancePath))
+			assert.NilError(t, err)
+			f, err := os.Open(filepath.Join("../..", spec.PythonSolutionPath))
+			assert.NilError(t, err)
+			defer f.Close()
+			reference, err := cover.ReadSolution(f)
+			assert.NilError(t, err)
+
+			for _, opts := range []DancingLinksOptions{{}, {LagrangianBound: true}} {
+				result, err := SolveByDancingLinksWithOptions(*ins, opts)
+				assert.NilError(t, err)
+				assert.Equal(t, cover.NewSolution(*ins, result).Status, reference.Status)
+				if result.ExactlyCovered {
+					assert.Assert(t, cover.Verify(*ins, result.SubsetsIndices).CostMatches(reference.Cost))
+				}
+			}
+		})
+	}
+}
+
+func TestDLXMatchesBB(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		ins := cover.MakeRandomInstance(20, 600, 1, seed)
+		expected, err := SolveByBranchAndBound(ins)
+		assert.NilError(t, err)
+		for _, opts := range []DancingLinksOptions{{}, {LagrangianBound: true}} {
+			result, err := SolveByDancingLinksWithOptions(ins, opts)
+			assert.NilError(t, err)
+			assert.Equal(t, result.ExactlyCovered, expected.ExactlyCovered)
+			assert.Assert(t, cover.CostsEqual(result.Cost, expected.Cost),
+				"dlx cost %v and bb cost %v", result.Cost, expected.Cost)
+		}
+	}
+}
+
+func TestFindExactCovers(t *testing.T) {
+	ins := cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0}, {1}, {2}, {0, 1}, {1, 2}, {0, 1, 2}},
+		Costs:        []float64{1, 1, 1, 1, 1, 1},
+	}
+	covers, err := FindExactCovers(ins, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(covers), 4)
+	for _, c := range covers {
+		assert.Assert(t, cover.Verify(ins, c).ExactlyCovered, "%v is not an exact cover", c)
+	}
+
+	covers, err = FindExactCovers(ins, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, len(covers), 2)
+}
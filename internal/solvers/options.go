@@ -0,0 +1,151 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"time"
+
+	"github.com/snow-abstraction/cover/internal/tree"
+)
+
+// Options are options for the branch-and-bound solver. The zero value has no
+// limits.
+type Options struct {
+	// The maximum number of nodes to process. 0 means no limit.
+	NodeLimit int
+	// The maximum time to spend. 0 means no limit.
+	TimeLimit time.Duration
+	// OnNode, if not nil, is called with each node once its Outcome is
+	// decided. If a limit is reached, it is also called with each node left
+	// open. The nodes' parents are valid so e.g. the whole tree can be
+	// exported with tree.WriteDOT.
+	OnNode func(node tree.Node)
+	// Recorder, if not nil, records every node like OnNode.
+	Recorder *Recorder
+	// OnProgress, if not nil, is called when a better exact cover is found
+	// and, if ProgressInterval > 0, at least every ProgressInterval while
+	// nodes are processed.
+	OnProgress       func(Progress)
+	ProgressInterval time.Duration
+	// InitialSolution, if not nil, are the subset indices of an exact cover,
+	// e.g. a known schedule, that is used as the best solution until a better
+	// one is found. An error is returned if it is not an exact cover.
+	InitialSolution []int
+	// OnIncumbent, if not nil, is called with the subset indices and cost of
+	// each better exact cover found. It is not called for InitialSolution.
+	OnIncumbent func(subsetIndices []int, cost float64)
+	// OnCheckpoint, if not nil, is called with a checkpoint of the search
+	// when a limit is reached and, if CheckpointInterval > 0, at least every
+	// CheckpointInterval. If it returns an error, the search is stopped and
+	// the error is returned.
+	OnCheckpoint       func(Checkpoint) error
+	CheckpointInterval time.Duration
+	// Resume, if not nil, is a checkpoint of the instance to continue the
+	// search from. Its incumbent is checked and used like InitialSolution and
+	// an error is returned if it is not an exact cover with the stated cost.
+	// Its nodes count towards NodeLimit and Stats.Nodes. The other statistics
+	// and the TimeLimit are for this run only. ErrCheckpointMismatch is
+	// returned if the checkpoint is of another instance.
+	Resume *Checkpoint
+}
+
+// Progress is the state of a branch-and-bound run, see Options.OnProgress.
+type Progress struct {
+	// The number of nodes processed and waiting to be processed.
+	Nodes     int
+	OpenNodes int
+	// A lower bound on the optimal cost.
+	Bound float64
+	// The cost of the best exact cover found or +Inf if none was found.
+	Incumbent float64
+	// The relative gap (Incumbent - Bound) / Incumbent or +Inf if no exact
+	// cover was found.
+	Gap     float64
+	Elapsed time.Duration
+	// If the call is due to a better exact cover being found.
+	NewIncumbent bool
+}
+
+// Stats are statistics about a branch-and-bound run.
+type Stats struct {
+	// The number of nodes processed.
+	Nodes int
+	// A lower bound on the optimal cost. If the search was completed, it is the
+	// optimal cost or +Inf if the instance is infeasible.
+	LowerBound float64
+	// If a limit of the Options was reached before the search was complete.
+	// Then the solution returned, if any, may not be optimal.
+	LimitReached bool
+	Time         time.Duration
+
+	// The number of nodes by outcome. The pruned nodes include those
+	// discarded without being processed since their parent's bound was not
+	// better than the best solution found.
+	PrunedNodes     int
+	InfeasibleNodes int
+	IntegralNodes   int
+	BranchedNodes   int
+	// The maximum number of nodes waiting to be processed.
+	MaxOpenNodes int
+
+	// The number of runs of the subgradient algorithm, one per node needing
+	// a bound, and their total number of iterations.
+	DualRuns              int
+	SubgradientIterations int
+
+	// The time spent creating the nodes' sub-instances, in the subgradient
+	// algorithm and choosing the branching elements.
+	SubInstanceTime time.Duration
+	DualTime        time.Duration
+	BranchingTime   time.Duration
+
+	// The lower bound at the root node. It is 0 if the root was not
+	// processed and +Inf if the instance was found infeasible at the root.
+	RootBound float64
+}
+
+// MeanSubgradientIterations is the mean number of iterations per run of the
+// subgradient algorithm or 0 if it was not run.
+func (s Stats) MeanSubgradientIterations() float64 {
+	if s.DualRuns == 0 {
+		return 0
+	}
+	return float64(s.SubgradientIterations) / float64(s.DualRuns)
+}
+
+// AddComponent adds the statistics of an independent component, e.g. solved
+// by SolveByComponents, so s becomes the statistics of the combined
+// instance. The lower and root bounds are summed and the maximum number of
+// open nodes is the maximum of the components.
+func (s *Stats) AddComponent(other Stats) {
+	s.Nodes += other.Nodes
+	s.LowerBound += other.LowerBound
+	s.LimitReached = s.LimitReached || other.LimitReached
+	s.Time += other.Time
+	s.PrunedNodes += other.PrunedNodes
+	s.InfeasibleNodes += other.InfeasibleNodes
+	s.IntegralNodes += other.IntegralNodes
+	s.BranchedNodes += other.BranchedNodes
+	s.MaxOpenNodes = max(s.MaxOpenNodes, other.MaxOpenNodes)
+	s.DualRuns += other.DualRuns
+	s.SubgradientIterations += other.SubgradientIterations
+	s.SubInstanceTime += other.SubInstanceTime
+	s.DualTime += other.DualTime
+	s.BranchingTime += other.BranchingTime
+	s.RootBound += other.RootBound
+}
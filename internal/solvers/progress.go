@@ -0,0 +1,52 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+)
+
+const progressHeader = "      Nodes       Open        Incumbent            Bound      Gap       Time"
+
+// LogProgress returns an Options.OnProgress callback that logs a table line
+// at Info level per call, in the style of MIP solvers. The table header is
+// logged before the first line. Lines for new incumbents start with a *.
+func LogProgress() func(Progress) {
+	var once sync.Once
+	return func(p Progress) {
+		once.Do(func() { slog.Info(progressHeader) })
+		slog.Info(formatProgress(p))
+	}
+}
+
+func formatProgress(p Progress) string {
+	marker := " "
+	if p.NewIncumbent {
+		marker = "*"
+	}
+	incumbent, gap := "-", "-"
+	if !math.IsInf(p.Incumbent, 1) {
+		incumbent = fmt.Sprintf("%.8g", p.Incumbent)
+		gap = fmt.Sprintf("%.2f%%", 100*p.Gap)
+	}
+	return fmt.Sprintf("%s%10d %10d %16s %16.8g %8s %9.2fs",
+		marker, p.Nodes, p.OpenNodes, incumbent, p.Bound, gap, p.Elapsed.Seconds())
+}
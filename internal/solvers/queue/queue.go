@@ -31,14 +31,32 @@ type LowerBoundPriorityQueue struct {
 
 func MakeQueue() LowerBoundPriorityQueue {
 	storage := make(pq, 0)
-	return LowerBoundPriorityQueue{storage}
+	return LowerBoundPriorityQueue{q: storage}
 }
-func (q *LowerBoundPriorityQueue) Push(node *tree.Node) {
+func (q *LowerBoundPriorityQueue) Push(node tree.Node) {
 	heap.Push(&q.q, &item{node: node})
 }
-func (q *LowerBoundPriorityQueue) Pop() *tree.Node {
+func (q *LowerBoundPriorityQueue) Pop() tree.Node {
 	return heap.Pop(&q.q).(*item).node
 }
+
+// Peek returns the node that Pop would return without removing it. The queue
+// must not be empty.
+func (q *LowerBoundPriorityQueue) Peek() tree.Node {
+	return q.q[0].node
+}
+
+// Nodes returns the nodes in the queue's internal order. Pushing them in
+// this order to an empty queue gives a queue that pops the nodes in the same
+// order as this queue.
+func (q *LowerBoundPriorityQueue) Nodes() []tree.Node {
+	nodes := make([]tree.Node, 0, len(q.q))
+	for _, item := range q.q {
+		nodes = append(nodes, item.node)
+	}
+	return nodes
+}
+
 func (q *LowerBoundPriorityQueue) Len() int {
 	return q.q.Len()
 }
@@ -46,7 +64,7 @@ func (q *LowerBoundPriorityQueue) Len() int {
 // An item is a node with its heap index.
 // Adapting from PriorityQueue example from https://pkg.go.dev/container/heap
 type item struct {
-	node  *tree.Node
+	node  tree.Node
 	index int
 }
 
@@ -56,7 +74,7 @@ type pq []*item
 
 func (q pq) Len() int { return len(q) }
 func (q pq) Less(i, j int) bool {
-	return q[i].node.LowerBound < q[j].node.LowerBound
+	return q[i].node.LowerBound() < q[j].node.LowerBound()
 }
 func (q pq) Swap(i, j int) {
 	q[i], q[j] = q[j], q[i]
@@ -80,7 +98,7 @@ func (pq *pq) Pop() any {
 }
 
 // Not needed yet.
-// func (pq *pq) Update(item *item, node *tree.Node) {
+// func (pq *pq) Update(item *item, node tree.Node) {
 // 	item.node = node
 // 	heap.Fix(pq, item.index)
 // }
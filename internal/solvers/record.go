@@ -0,0 +1,168 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/snow-abstraction/cover/internal/tree"
+)
+
+// NodeRecord is what a Recorder keeps about one branch-and-bound node.
+type NodeRecord struct {
+	// The node's id. The ids are assigned in the order the nodes are recorded,
+	// starting at 0.
+	ID int `json:"id"`
+	// The id of the node's parent or -1 for a root node. With
+	// SolveByComponents there is one root per component.
+	Parent int `json:"parent"`
+	Depth  int `json:"depth"`
+	// root, both or diff
+	Kind string `json:"kind"`
+	// The branching elements. Both are 0 for a root node.
+	I uint32 `json:"i"`
+	J uint32 `json:"j"`
+	// The size of the node's sub-instance. Both are 0 if the sub-instance was
+	// not created, e.g. if the node was pruned by its parent's bound.
+	Elements int `json:"elements"`
+	Subsets  int `json:"subsets"`
+	// The Lagrangian dual bound of the node's sub-instance and the
+	// subgradient iterations used to find it. nil and 0 if not calculated.
+	DualBound  *float64 `json:"dualBound,omitempty"`
+	Iterations int      `json:"iterations"`
+	// The dual objective values during the subgradient iterations, sampled
+	// at exponentially spaced iterations and ending with DualBound.
+	Convergence []float64 `json:"convergence,omitempty"`
+	// The cost of the best exact cover found, if any, and the lower bound on
+	// the optimal cost when the node was recorded. The bound is nil if it is
+	// +Inf, i.e. the instance was proven infeasible. With SolveByComponents,
+	// they are for the node's component.
+	Incumbent *float64 `json:"incumbent,omitempty"`
+	Bound     *float64 `json:"bound,omitempty"`
+	// The tree.Outcome of the node, e.g. pruned or branched.
+	Outcome string `json:"outcome"`
+	// Seconds since the recorder was created.
+	Time float64 `json:"time"`
+}
+
+// Recorder records the nodes processed by the branch-and-bound solver, see
+// Options.Recorder. It is safe for concurrent use so one recorder can be
+// shared by the components solved by SolveByComponents.
+type Recorder struct {
+	mu      sync.Mutex
+	start   time.Time
+	ids     map[tree.Node]int
+	records []NodeRecord
+}
+
+// NewRecorder creates an empty recorder. The record times are relative to
+// when it is created.
+func NewRecorder() *Recorder {
+	return &Recorder{start: time.Now(), ids: make(map[tree.Node]int)}
+}
+
+// Records returns a copy of the records in the order they were recorded. A
+// node's parent is always recorded before the node.
+func (r *Recorder) Records() []NodeRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]NodeRecord(nil), r.records...)
+}
+
+// ReadJSONLines reads records written by WriteJSONLines.
+func ReadJSONLines(r io.Reader) ([]NodeRecord, error) {
+	var records []NodeRecord
+	dec := json.NewDecoder(r)
+	for {
+		var rec NodeRecord
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("node record %d: %w", len(records), err)
+		}
+		records = append(records, rec)
+	}
+}
+
+// WriteJSONLines writes the records as JSON Lines, i.e. one JSON object per
+// line.
+func (r *Recorder) WriteJSONLines(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, rec := range r.Records() {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// record records the node, whose outcome must be set, and what is known
+// about its sub-instance. sub, dual and incumbent may be nil.
+func (r *Recorder) record(node tree.Node, sub *subInstance, dual *lagrangianDualResult,
+	incumbent *float64, bound float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec := NodeRecord{
+		ID:        len(r.records),
+		Parent:    -1,
+		Outcome:   node.Outcome().String(),
+		Time:      time.Since(r.start).Seconds(),
+		Incumbent: incumbent,
+	}
+	if !math.IsInf(bound, 1) {
+		rec.Bound = &bound
+	}
+	switch node.Kind() {
+	case tree.Root:
+		rec.Kind = "root"
+	case tree.BothBranch:
+		rec.Kind = "both"
+	case tree.DiffBranch:
+		rec.Kind = "diff"
+	}
+	if node.Kind() != tree.Root {
+		rec.I, rec.J = node.I(), node.J()
+	}
+	if parentID, found := r.ids[node.Parent()]; found {
+		rec.Parent = parentID
+		rec.Depth = r.records[parentID].Depth + 1
+	}
+	if sub != nil {
+		rec.Elements = sub.ins.m
+		rec.Subsets = len(sub.ins.subsets)
+	}
+	if dual != nil {
+		bound := dual.dualObjectiveValue
+		rec.DualBound = &bound
+		rec.Iterations = dual.iterations
+		rec.Convergence = dual.trace
+	}
+
+	r.ids[node] = rec.ID
+	r.records = append(r.records, rec)
+}
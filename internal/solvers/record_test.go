@@ -0,0 +1,60 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"gotest.tools/v3/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	ins := cover.MakeRandomInstance(20, 600, 1, 3)
+	recorder := NewRecorder()
+	_, stats, err := SolveByBranchAndBoundWithOptions(ins, Options{Recorder: recorder})
+	assert.NilError(t, err)
+
+	records := recorder.Records()
+	assert.Assert(t, len(records) >= stats.Nodes)
+	assert.Equal(t, records[0].Kind, "root")
+	assert.Equal(t, records[0].Parent, -1)
+	assert.Equal(t, records[0].Outcome, "branched")
+	assert.Equal(t, records[0].Elements, 20)
+	assert.Assert(t, records[0].DualBound != nil)
+	assert.Assert(t, records[0].Iterations > 0)
+	assert.Equal(t, records[0].Convergence[len(records[0].Convergence)-1], *records[0].DualBound)
+	assert.Assert(t, records[0].Bound != nil)
+	for _, rec := range records[1:] {
+		assert.Assert(t, rec.Parent >= 0 && rec.Parent < rec.ID)
+		assert.Equal(t, rec.Depth, records[rec.Parent].Depth+1)
+		assert.Equal(t, records[rec.Parent].Outcome, "branched")
+	}
+
+	var buf bytes.Buffer
+	assert.NilError(t, recorder.WriteJSONLines(&buf))
+	dec := json.NewDecoder(&buf)
+	for _, want := range records {
+		var got NodeRecord
+		assert.NilError(t, dec.Decode(&got))
+		assert.DeepEqual(t, got, want)
+	}
+	assert.Assert(t, !dec.More())
+}
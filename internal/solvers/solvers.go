@@ -19,26 +19,78 @@ package solvers
 
 import "github.com/snow-abstraction/cover"
 
-// SolveByBranchAndBound exposes an internal method without the suffix `Internal“
+// SolveByBranchAndBound exposes an internal method without the suffix `Internal`
 // and takes and returns exported types.
 func SolveByBranchAndBound(ins cover.Instance) (cover.SubsetsEval, error) {
-	solverInstance, err := MakeInstance(ins.ElementCount, ins.Subsets, ins.Costs)
+	solverInstance, err := makeInstanceFromCover(ins)
 	if err != nil {
 		return cover.SubsetsEval{}, err
 	}
 
 	sol, err := SolveByBranchAndBoundInternal(solverInstance)
+	sol.SubsetNames = ins.SubsetNamesOf(sol.SubsetsIndices)
 	return cover.SubsetsEval(sol), err
 }
 
-// SolveByBruteForce exposes an internal method without the suffix `Internal“
+// SolveByBranchAndBoundWithOptions is SolveByBranchAndBound with limits and
+// statistics.
+func SolveByBranchAndBoundWithOptions(ins cover.Instance, opts Options) (cover.SubsetsEval, Stats, error) {
+	solverInstance, err := makeInstanceFromCover(ins)
+	if err != nil {
+		return cover.SubsetsEval{}, Stats{}, err
+	}
+
+	sol, stats, err := solveByBranchAndBound(solverInstance, opts)
+	sol.SubsetNames = ins.SubsetNamesOf(sol.SubsetsIndices)
+	return cover.SubsetsEval(sol), stats, err
+}
+
+// SolveByBruteForce exposes an internal method without the suffix `Internal`
 // and takes and returns exported types.
 func SolveByBruteForce(ins cover.Instance) (cover.SubsetsEval, error) {
-	solverInstance, err := MakeInstance(ins.ElementCount, ins.Subsets, ins.Costs)
+	solverInstance, err := makeInstanceFromCover(ins)
 	if err != nil {
 		return cover.SubsetsEval{}, err
 	}
 
 	sol, err := SolveByBruteForceInternal(solverInstance)
+	sol.SubsetNames = ins.SubsetNamesOf(sol.SubsetsIndices)
 	return cover.SubsetsEval(sol), err
 }
+
+// SolveByDancingLinks exposes an internal method without the suffix
+// `Internal` and takes and returns exported types.
+func SolveByDancingLinks(ins cover.Instance) (cover.SubsetsEval, error) {
+	return SolveByDancingLinksWithOptions(ins, DancingLinksOptions{})
+}
+
+// SolveByDancingLinksWithOptions is SolveByDancingLinks with options.
+func SolveByDancingLinksWithOptions(ins cover.Instance, opts DancingLinksOptions) (cover.SubsetsEval, error) {
+	solverInstance, err := makeInstanceFromCover(ins)
+	if err != nil {
+		return cover.SubsetsEval{}, err
+	}
+
+	sol, err := SolveByDancingLinksInternal(solverInstance, opts)
+	sol.SubsetNames = ins.SubsetNamesOf(sol.SubsetsIndices)
+	return cover.SubsetsEval(sol), err
+}
+
+// FindExactCovers exposes an internal method without the suffix `Internal`
+// and takes exported types.
+func FindExactCovers(ins cover.Instance, k int) ([][]int, error) {
+	solverInstance, err := makeInstanceFromCover(ins)
+	if err != nil {
+		return nil, err
+	}
+	return FindExactCoversInternal(solverInstance, k), nil
+}
+
+// makeInstanceFromCover validates the whole instance, including the names,
+// using cover.Validate and then makes an Instance from it.
+func makeInstanceFromCover(ins cover.Instance) (instance, error) {
+	if err := cover.Validate(ins); err != nil {
+		return instance{}, err
+	}
+	return makeValidatedInstance(ins.ElementCount, ins.Subsets, ins.Costs), nil
+}
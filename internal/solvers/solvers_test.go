@@ -0,0 +1,61 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package solvers
+
+import (
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"gotest.tools/v3/assert"
+)
+
+// namedInstance has more expensive duplicates so the subset indices are
+// remapped by removeMoreExpensiveDuplicates before the names are looked up.
+func namedInstance() cover.Instance {
+	return cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1}, {2}, {0, 1}, {0}, {1, 2}, {2}},
+		Costs:        []float64{5, 4, 2, 1, 7, 3},
+		ElementNames: []string{"a", "b", "c"},
+		SubsetNames:  []string{"ab1", "c1", "ab2", "a", "bc", "c2"},
+	}
+}
+
+func TestBBReportsSubsetNames(t *testing.T) {
+	result, err := SolveByBranchAndBound(namedInstance())
+	assert.NilError(t, err)
+	assert.Assert(t, result.Optimal)
+	assert.Equal(t, result.Cost, 5.0)
+	assert.DeepEqual(t, result.SubsetNames, []string{"ab2", "c2"})
+}
+
+func TestBruteReportsSubsetNames(t *testing.T) {
+	result, err := SolveByBruteForce(namedInstance())
+	assert.NilError(t, err)
+	assert.Assert(t, result.Optimal)
+	assert.DeepEqual(t, result.SubsetNames, []string{"ab2", "c2"})
+}
+
+func TestUnnamedInstanceHasNoSubsetNames(t *testing.T) {
+	ins := namedInstance()
+	ins.ElementNames = nil
+	ins.SubsetNames = nil
+	result, err := SolveByBranchAndBound(ins)
+	assert.NilError(t, err)
+	assert.Assert(t, result.SubsetNames == nil)
+}
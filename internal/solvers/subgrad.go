@@ -28,6 +28,11 @@ type lagrangianDualResult struct {
 	provenOptimalExact bool
 	// Index of element not covered exactly. -1 if all covered exactly.
 	notCoveredExactly int
+	// The number of subgradient iterations run.
+	iterations int
+	// The dual objective values at the iterations where the status was
+	// checked, ending with dualObjectiveValue.
+	trace []float64
 }
 
 // Calculate a lower bound for the (non-exact) set covering problem instance specified by
@@ -103,6 +108,7 @@ func runDualIterations(aC cCSMatrix /* C for column storage*/, costs []float64)
 	// max iterations
 	n := 1000
 	nextCheckStatus := 1
+	var trace []float64
 
 	for k := 0; k < n; k++ {
 		// TODO: use a better step length rule
@@ -141,6 +147,8 @@ func runDualIterations(aC cCSMatrix /* C for column storage*/, costs []float64)
 		if isSubgradientZero {
 			result := calcLagrangianDualResult(nCols, costs, x, aR, aRx, nRows, u)
 			slog.Debug("Stop iterating. Subgradient zero")
+			result.iterations = k + 1
+			result.trace = append(trace, result.dualObjectiveValue)
 			return result, nil
 		}
 
@@ -160,14 +168,20 @@ func runDualIterations(aC cCSMatrix /* C for column storage*/, costs []float64)
 			nextCheckStatus *= 2
 			result := calcLagrangianDualResult(nCols, costs, x, aR, aRx, nRows, u)
 			slog.Debug("Iteration status", "i", k, "objective value", result.dualObjectiveValue)
+			trace = append(trace, result.dualObjectiveValue)
 			if result.provenOptimalExact {
 				slog.Debug("Stop iterating. Proven optimal")
+				result.iterations = k + 1
+				result.trace = trace
 				return result, nil
 			}
 		}
 	}
 
-	return calcLagrangianDualResult(nCols, costs, x, aR, aRx, nRows, u), nil
+	result := calcLagrangianDualResult(nCols, costs, x, aR, aRx, nRows, u)
+	result.iterations = n
+	result.trace = append(trace, result.dualObjectiveValue)
+	return result, nil
 }
 
 func calcMeanElementCost(aC cCSMatrix, costs []float64, nCols int) float64 {
@@ -0,0 +1,111 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package tree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+)
+
+// dotStyle is the Graphviz node style of each outcome.
+var dotStyle = map[Outcome]string{
+	Open:       `style=dashed`,
+	Pruned:     `style=filled, fillcolor=lightgray`,
+	Infeasible: `style=filled, fillcolor=lightpink`,
+	Integral:   `style=filled, fillcolor=palegreen`,
+	Branched:   `style=solid`,
+}
+
+// WriteDOT writes the tree of the nodes and all their ancestors in the
+// Graphviz DOT language, e.g. for rendering with "dot -Tsvg". Each node is
+// labeled with its kind, the branching elements (I, J), its lower bound and
+// its outcome. The nodes may belong to several trees, e.g. one per connected
+// component, which are written as one graph.
+func WriteDOT(w io.Writer, nodes []Node) error {
+	var roots []*printNode
+	m := make(map[Node]*printNode)
+	for _, node := range nodes {
+		r, err := add(m, node)
+		if err != nil {
+			return err
+		}
+		if !containsRoot(roots, r) {
+			roots = append(roots, r)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph bb {")
+	fmt.Fprintln(bw, "  node [shape=box, fontname=\"monospace\"];")
+	id := 0
+	for _, root := range roots {
+		writeDOTNode(bw, root, &id)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func containsRoot(roots []*printNode, r *printNode) bool {
+	for _, root := range roots {
+		if root == r {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDOTNode writes the node and its descendants in depth first order and
+// returns the node's id. The ids are assigned in that order.
+func writeDOTNode(w io.Writer, pn *printNode, nextID *int) int {
+	id := *nextID
+	*nextID++
+
+	node := pn.referenceNode
+	fmt.Fprintf(w, "  n%d [label=\"%s\", %s];\n", id, dotLabel(node), dotStyle[node.Outcome()])
+	for _, child := range []*printNode{pn.bothBranchChild, pn.diffBranchChild} {
+		if child == nil {
+			continue
+		}
+		childID := writeDOTNode(w, child, nextID)
+		fmt.Fprintf(w, "  n%d -> n%d;\n", id, childID)
+	}
+	return id
+}
+
+func dotLabel(node Node) string {
+	var kind string
+	switch node.Kind() {
+	case Root:
+		kind = "root"
+	case BothBranch:
+		kind = fmt.Sprintf("both (%d, %d)", node.I(), node.J())
+	case DiffBranch:
+		kind = fmt.Sprintf("diff (%d, %d)", node.I(), node.J())
+	default:
+		kind = fmt.Sprintf("kind %d", node.Kind())
+	}
+
+	// The root's lower bound is a placeholder.
+	bound := "-"
+	if node.Kind() != Root && node.LowerBound() != math.MaxFloat64 {
+		bound = fmt.Sprintf("%.6g", node.LowerBound())
+	}
+	return fmt.Sprintf("%s\\nLB %s\\n%s", kind, bound, node.Outcome())
+}
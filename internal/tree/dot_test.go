@@ -0,0 +1,61 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package tree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWriteDOT(t *testing.T) {
+	root := CreateRoot()
+	root.SetOutcome(Branched)
+	both, diff := root.Branch(2.5, 0, 1)
+	both.SetOutcome(Integral)
+	diff.SetOutcome(Pruned)
+
+	var buf bytes.Buffer
+	assert.NilError(t, WriteDOT(&buf, []Node{both, diff}))
+	assert.Equal(t, buf.String(), `digraph bb {
+  node [shape=box, fontname="monospace"];
+  n0 [label="root\nLB -\nbranched", style=solid];
+  n1 [label="both (0, 1)\nLB 2.5\nintegral", style=filled, fillcolor=palegreen];
+  n0 -> n1;
+  n2 [label="diff (0, 1)\nLB 2.5\npruned", style=filled, fillcolor=lightgray];
+  n0 -> n2;
+}
+`)
+}
+
+func TestWriteDOTSeveralRoots(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, WriteDOT(&buf, []Node{CreateRoot(), CreateRoot()}))
+	assert.Equal(t, strings.Count(buf.String(), "root"), 2)
+}
+
+func TestFprintTreeVisitsBothChildren(t *testing.T) {
+	root := CreateRoot()
+	both, diff := root.Branch(1, 0, 1)
+
+	var buf bytes.Buffer
+	assert.NilError(t, FprintTree(&buf, []Node{both, diff}))
+	assert.Equal(t, strings.Count(buf.String(), "\n"), 3)
+}
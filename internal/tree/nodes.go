@@ -35,41 +35,178 @@ const (
 	DiffBranch = 2
 )
 
+// Tree is an arena of the nodes of one branch-and-bound tree. Huge trees are
+// kept in memory, so a node is not an object with a parent pointer. Instead
+// the two children of a branched node share one branching record, which
+// refers to the parent by its int32 index, and the only per node field is the
+// outcome. Thus a node takes 13 bytes.
+//
+// The root is node 0 and the children of the b:th branching are node 2b+1
+// (the "both" branch) and node 2b+2 (the "diff" branch).
+type Tree struct {
+	branchings []branching
+	outcomes   []Outcome
+}
+
+// branching is what the two children of a branched node share.
+type branching struct {
+	lowerBound float64
+	parent     int32
+	// Elements constrained, depending on NodeKind
+	i uint32
+	j uint32
+}
+
 // constraint branch-and-bound Node
 // The subproblem the Node represents can be calculated by applying
 // the branch type of it and its ancestors.
 // TODO: enforce I < J
+//
+// A Node refers to a node of a Tree and is compared by identity. The zero
+// Node refers to no node, e.g. it is the parent of the root.
 type Node struct {
-	Kind       NodeKind
-	Parent     *Node // nil if root node
-	LowerBound float64
-	// Elements constrained, depending on NodeKind
-	// The following have no meaning for the root node
-	I uint32
-	J uint32
+	tree *Tree
+	id   int32
 }
 
-func CreateRoot() *Node {
-	return &Node{Root, nil, math.MaxFloat64, math.MaxUint32, math.MaxUint32}
+// Outcome is what happened when a node was processed.
+type Outcome byte
+
+const (
+	// The node has not been processed.
+	Open Outcome = iota
+	// The node was discarded since its lower bound was not better than the
+	// best solution found.
+	Pruned
+	// The node's sub-instance has no exact cover.
+	Infeasible
+	// An optimal exact cover of the node's sub-instance was found so the node
+	// needs no branching.
+	Integral
+	// The node was branched into two children.
+	Branched
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Open:
+		return "open"
+	case Pruned:
+		return "pruned"
+	case Infeasible:
+		return "infeasible"
+	case Integral:
+		return "integral"
+	case Branched:
+		return "branched"
+	}
+	return fmt.Sprintf("Outcome(%d)", byte(o))
 }
 
-func CreateInitialNodes() []*Node {
-	return []*Node{CreateRoot()}
+// NewTree creates a tree with only a root node.
+func NewTree() *Tree {
+	return &Tree{outcomes: []Outcome{Open}}
+}
+
+// Root returns the root node of the tree.
+func (t *Tree) Root() Node {
+	return Node{t, 0}
+}
+
+// Len returns the number of nodes of the tree.
+func (t *Tree) Len() int {
+	return len(t.outcomes)
+}
+
+// CreateRoot creates a new tree and returns its root.
+func CreateRoot() Node {
+	return NewTree().Root()
+}
+
+func CreateInitialNodes() []Node {
+	return []Node{CreateRoot()}
 }
 
 // Branches the parent on the two constrains to create two new Nodes
-func (parent *Node) Branch(lowerBound float64, branchConstraintOne uint32,
-	branchConstraintTwo uint32) (*Node, *Node) {
+func (parent Node) Branch(lowerBound float64, branchConstraintOne uint32,
+	branchConstraintTwo uint32) (Node, Node) {
 
-	return &Node{BothBranch, parent, lowerBound, branchConstraintOne, branchConstraintTwo},
-		&Node{DiffBranch, parent, lowerBound, branchConstraintOne, branchConstraintTwo}
+	t := parent.tree
+	if len(t.outcomes) > math.MaxInt32-2 {
+		panic("tree: too many nodes")
+	}
+	t.branchings = append(t.branchings, branching{lowerBound, parent.id, branchConstraintOne, branchConstraintTwo})
+	t.outcomes = append(t.outcomes, Open, Open)
+	id := int32(len(t.outcomes))
+	return Node{t, id - 2}, Node{t, id - 1}
+}
 
+// branching returns the node's branching. The node must not be the root.
+func (n Node) branching() *branching {
+	return &n.tree.branchings[(n.id-1)/2]
 }
 
-func (n *Node) LogValue() slog.Value {
-	if n == nil {
-		return slog.StringValue("nil")
+func (n Node) Kind() NodeKind {
+	switch {
+	case n.id == 0:
+		return Root
+	case n.id%2 == 1:
+		return BothBranch
+	default:
+		return DiffBranch
 	}
+}
+
+// Parent returns the parent of the node or the zero Node if it is the root.
+func (n Node) Parent() Node {
+	if n.id == 0 {
+		return Node{}
+	}
+	return Node{n.tree, n.branching().parent}
+}
+
+// LowerBound returns the lower bound the node was branched with, which for
+// the root is math.MaxFloat64.
+func (n Node) LowerBound() float64 {
+	if n.id == 0 {
+		return math.MaxFloat64
+	}
+	return n.branching().lowerBound
+}
+
+// I returns the first element constrained. It has no meaning for the root.
+func (n Node) I() uint32 {
+	if n.id == 0 {
+		return math.MaxUint32
+	}
+	return n.branching().i
+}
+
+// J returns the second element constrained. It has no meaning for the root.
+func (n Node) J() uint32 {
+	if n.id == 0 {
+		return math.MaxUint32
+	}
+	return n.branching().j
+}
+
+// Outcome returns what happened when the node was processed by the solver.
+func (n Node) Outcome() Outcome {
+	return n.tree.outcomes[n.id]
+}
+
+func (n Node) SetOutcome(o Outcome) {
+	n.tree.outcomes[n.id] = o
+}
+
+func (n Node) String() string {
+	if n == (Node{}) {
+		return "nil"
+	}
+	return fmt.Sprintf("{Kind:%d LowerBound:%v I:%d J:%d Outcome:%s}",
+		n.Kind(), n.LowerBound(), n.I(), n.J(), n.Outcome())
+}
 
-	return slog.StringValue(fmt.Sprintf("%+v", *n))
+func (n Node) LogValue() slog.Value {
+	return slog.StringValue(n.String())
 }
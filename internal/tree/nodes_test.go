@@ -0,0 +1,67 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package tree
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNodeIsCompact(t *testing.T) {
+	// The two children of a branched node share one branching record and
+	// each has its outcome, so a node takes 13 bytes on any platform.
+	perNode := (unsafe.Sizeof(branching{}) + 2*unsafe.Sizeof(Outcome(0))) / 2
+	assert.Equal(t, perNode, uintptr(13))
+
+	tr := NewTree()
+	node := tr.Root()
+	for k := 0; k < 1000; k++ {
+		node, _ = node.Branch(float64(k), uint32(k), uint32(k+1))
+	}
+	assert.Equal(t, tr.Len(), 2001)
+	assert.Equal(t, len(tr.branchings), 1000)
+	assert.Equal(t, len(tr.outcomes), 2001)
+}
+
+func TestNodeAccessors(t *testing.T) {
+	root := CreateRoot()
+	assert.Equal(t, root.Kind(), NodeKind(Root))
+	assert.Equal(t, root.Parent(), Node{})
+	assert.Equal(t, root.LowerBound(), math.MaxFloat64)
+	assert.Equal(t, root.Outcome(), Open)
+
+	both, diff := root.Branch(2.5, 3, 4)
+	grandchild, _ := diff.Branch(3.5, 0, 1)
+	assert.Equal(t, both.Kind(), NodeKind(BothBranch))
+	assert.Equal(t, diff.Kind(), NodeKind(DiffBranch))
+	assert.Equal(t, both.Parent(), root)
+	assert.Equal(t, diff.Parent(), root)
+	assert.Equal(t, grandchild.Parent(), diff)
+	assert.Equal(t, diff.LowerBound(), 2.5)
+	assert.Equal(t, grandchild.LowerBound(), 3.5)
+	assert.Equal(t, diff.I(), uint32(3))
+	assert.Equal(t, diff.J(), uint32(4))
+
+	diff.SetOutcome(Branched)
+	assert.Equal(t, diff.Outcome(), Branched)
+	assert.Equal(t, both.Outcome(), Open)
+	assert.Assert(t, CreateRoot() != root)
+}
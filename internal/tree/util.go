@@ -20,11 +20,13 @@ package tree
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 )
 
 // For printing the implicit tree struct of Nodes
 type printNode struct {
-	referenceNode   *Node
+	referenceNode   Node
 	bothBranchChild *printNode
 	diffBranchChild *printNode
 }
@@ -32,9 +34,9 @@ type printNode struct {
 // For the start node and its ancestors, create corresponding PrintNodes if
 // they are not already in printNodeByNode. And set the links for the PrintNodes
 // from the parent to its children.
-func add(printNodeByNode map[*Node]*printNode, start *Node) (*printNode, error) {
+func add(printNodeByNode map[Node]*printNode, start Node) (*printNode, error) {
 	curr := start // curr = current
-	var prev *Node
+	var prev Node
 
 	var prevPNode *printNode
 	var currPNode *printNode
@@ -42,7 +44,7 @@ func add(printNodeByNode map[*Node]*printNode, start *Node) (*printNode, error)
 	// Isn't necessary to actually follow parents to the root node
 	// if a node is already in printNodeByNode, but we do so to
 	// check for errors.
-	for curr != nil {
+	for curr != (Node{}) {
 		var ok bool
 		currPNode, ok = printNodeByNode[curr]
 		if !ok {
@@ -50,67 +52,72 @@ func add(printNodeByNode map[*Node]*printNode, start *Node) (*printNode, error)
 			printNodeByNode[curr] = currPNode
 		}
 
-		if prev != nil {
-			switch prev.Kind {
+		if prev != (Node{}) {
+			switch prev.Kind() {
 			case Root:
-				return nil, fmt.Errorf("node of kind root has a non-nil parent %+v", *curr)
+				return nil, fmt.Errorf("node of kind root has a non-nil parent %v", curr)
 			case BothBranch:
 				if currPNode.bothBranchChild == nil {
 					currPNode.bothBranchChild = prevPNode
 				} else if currPNode.bothBranchChild != prevPNode {
 					return nil, fmt.Errorf(
-						"bothBranchChild set before to a different node for node %+v", *curr)
+						"bothBranchChild set before to a different node for node %v", curr)
 				}
 			case DiffBranch:
 				if currPNode.diffBranchChild == nil {
 					currPNode.diffBranchChild = prevPNode
 				} else if currPNode.diffBranchChild != prevPNode {
 					return nil, fmt.Errorf(
-						"diffBranchChild set before to a different node for node %+v", *curr)
+						"diffBranchChild set before to a different node for node %v", curr)
 				}
 			default:
-				return nil, fmt.Errorf("unknown kind for for node %+v", *curr)
+				return nil, fmt.Errorf("unknown kind for for node %v", curr)
 
 			}
 		}
 
 		prev = curr
 		prevPNode = currPNode
-		curr = curr.Parent
+		curr = curr.Parent()
 
 	}
 
 	return currPNode, nil
 }
 
-func printImpl(depth int, node *printNode) {
+func printImpl(w io.Writer, depth int, node *printNode) {
 	if node == nil {
 		return
 	}
 
 	for i := 0; i < depth; i++ {
-		fmt.Printf(" ")
+		fmt.Fprintf(w, " ")
 	}
 
-	fmt.Printf("%+v\n", *node.referenceNode)
-	printImpl(depth+2, node.diffBranchChild)
-	printImpl(depth+2, node.diffBranchChild)
+	fmt.Fprintf(w, "%v\n", node.referenceNode)
+	printImpl(w, depth+2, node.bothBranchChild)
+	printImpl(w, depth+2, node.diffBranchChild)
 
 }
 
 // For the nodes, find all ancestors and print the tree of nodes
 // All the supplied nodes, must have the same root.
-func PrintTree(nodes []*Node) error {
+func PrintTree(nodes []Node) error {
+	return FprintTree(os.Stdout, nodes)
+}
+
+// FprintTree is PrintTree writing to w.
+func FprintTree(w io.Writer, nodes []Node) error {
 	if len(nodes) == 0 {
 		return nil
 	}
 
 	var root *printNode
-	m := make(map[*Node]*printNode)
+	m := make(map[Node]*printNode)
 	for _, node := range nodes {
 		r, err := add(m, node)
 		if err != nil {
-			return nil
+			return err
 		}
 
 		if root != nil && r != root {
@@ -119,6 +126,6 @@ func PrintTree(nodes []*Node) error {
 		root = r
 	}
 
-	printImpl(0, root)
+	printImpl(w, 0, root)
 	return nil
 }
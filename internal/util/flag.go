@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 // Embedding of flag.FlatSet to have a connivent Parse()
@@ -40,13 +41,19 @@ type FlagSet struct {
 // Arguments:
 // `
 func NewFlagSet(usage string) *FlagSet {
-	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	return NewCommandFlagSet(os.Args[0], usage)
+}
+
+// NewCommandFlagSet is like NewFlagSet but the command name used in the
+// usage string is name, e.g. "cover solve" for a subcommand.
+func NewCommandFlagSet(name string, usage string) *FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
 	fs.Usage = func() {
 		fmt.Fprintf(
-			flag.CommandLine.Output(),
+			fs.Output(),
 			usage,
-			os.Args[0],
-			os.Args[0])
+			name,
+			name)
 		fs.PrintDefaults()
 	}
 
@@ -59,3 +66,52 @@ func NewFlagSet(usage string) *FlagSet {
 func (fs *FlagSet) Parse() {
 	fs.FlagSet.Parse(os.Args[1:])
 }
+
+// ParseArgs parses the flags from args, e.g. the arguments after a
+// subcommand's name.
+func (fs *FlagSet) ParseArgs(args []string) {
+	fs.FlagSet.Parse(args)
+}
+
+// Subcommand is a subcommand of a program with subcommands, e.g. "solve" in
+// "cover solve -instance instance.json".
+type Subcommand struct {
+	Name    string
+	Summary string // one line description shown in the program's usage
+	// Run runs the subcommand. name is the program and subcommand name, e.g.
+	// "cover solve", and args are the arguments after the subcommand name.
+	Run func(name string, args []string)
+}
+
+// RunSubcommand runs the subcommand named by os.Args[1]. If there is no such
+// subcommand, the usage is printed and the program exits with status 2 like
+// for invalid flags.
+func RunSubcommand(description string, subcommands []Subcommand) {
+	program := filepath.Base(os.Args[0])
+	usage := func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s <subcommand> [arguments]\n\n%s\n\nSubcommands:\n", program, description)
+		for _, sc := range subcommands {
+			fmt.Fprintf(os.Stderr, "  %-10s %s\n", sc.Name, sc.Summary)
+		}
+		fmt.Fprintf(os.Stderr, "\nRun '%s <subcommand> -h' for the arguments of a subcommand.\n", program)
+	}
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		usage()
+		os.Exit(0)
+	}
+	for _, sc := range subcommands {
+		if sc.Name == name {
+			sc.Run(program+" "+name, os.Args[2:])
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "unknown subcommand %s\n\n", name)
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,297 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Readers and writers for the OR-Library formats of J. E. Beasley's
+// set covering (e.g. scp41.txt) and set partitioning (e.g. sppnw01.txt)
+// test problems. The formats have no names, so names are neither read nor
+// written. The element and subset indices are 1-based in the files.
+
+package cover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// orlibScanner reads the white space separated numbers of an OR-Library file.
+type orlibScanner struct {
+	format  string
+	scanner *bufio.Scanner
+}
+
+func newORLibScanner(r io.Reader, format string) *orlibScanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	return &orlibScanner{format, scanner}
+}
+
+func (s *orlibScanner) next(what string) (string, error) {
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("OR-Library %s: unexpected end of file when reading %s", s.format, what)
+	}
+	return s.scanner.Text(), nil
+}
+
+func (s *orlibScanner) nextInt(what string) (int, error) {
+	token, err := s.next(what)
+	if err != nil {
+		return 0, err
+	}
+	x, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("OR-Library %s: unable to parse '%s' as an integer for %s", s.format, token, what)
+	}
+	return x, nil
+}
+
+// nextIndex reads a 1-based index in [1, n] and returns it 0-based.
+func (s *orlibScanner) nextIndex(what string, n int) (int, error) {
+	idx, err := s.nextInt(what)
+	if err != nil {
+		return 0, err
+	}
+	if idx < 1 || idx > n {
+		return 0, fmt.Errorf("OR-Library %s: %s %d is not in [1, %d]", s.format, what, idx, n)
+	}
+	return idx - 1, nil
+}
+
+func (s *orlibScanner) nextFloat(what string) (float64, error) {
+	token, err := s.next(what)
+	if err != nil {
+		return 0, err
+	}
+	x, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return 0, fmt.Errorf("OR-Library %s: unable to parse '%s' as a number for %s", s.format, token, what)
+	}
+	return x, nil
+}
+
+func (s *orlibScanner) nextCount(what string) (int, error) {
+	x, err := s.nextInt(what)
+	if err == nil && x < 0 {
+		err = fmt.Errorf("OR-Library %s: %s %d is negative", s.format, what, x)
+	}
+	return x, err
+}
+
+// checkEnd checks that only white space remains.
+func (s *orlibScanner) checkEnd() error {
+	if s.scanner.Scan() {
+		return fmt.Errorf("OR-Library %s: unexpected '%s' after the end of the instance", s.format, s.scanner.Text())
+	}
+	return s.scanner.Err()
+}
+
+// ReadORLibSCPInstance reads an instance from a file in the OR-Library set
+// covering format, which may be compressed. See ReadORLibSCP.
+func ReadORLibSCPInstance(filename string) (*Instance, error) {
+	return readInstanceFile(filename, ReadORLibSCP)
+}
+
+// ReadORLibSCP reads an instance in the row oriented OR-Library set covering
+// format:
+//
+//	number of rows (m), number of columns (n)
+//	the cost of each column c(j), j = 1, ..., n
+//	for each row i (i = 1, ..., m): the number of columns which cover row i
+//	followed by a list of the columns which cover row i
+//
+// The rows are the elements and the columns are the subsets. Note that the
+// solvers in this module find exact covers.
+func ReadORLibSCP(r io.Reader) (*Instance, error) {
+	s := newORLibScanner(r, "SCP")
+	m, err := s.nextCount("the number of rows")
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.nextCount("the number of columns")
+	if err != nil {
+		return nil, err
+	}
+
+	// The counts are not trusted for allocations since they may be corrupt.
+	ins := Instance{ElementCount: m, Costs: make([]float64, 0, min(n, maxPreallocation))}
+	for j := 0; j < n; j++ {
+		cost, err := s.nextFloat(fmt.Sprintf("the cost of column %d", j+1))
+		if err != nil {
+			return nil, err
+		}
+		ins.Costs = append(ins.Costs, cost)
+	}
+	// All n costs were read, so n is not larger than the file.
+	ins.Subsets = make([][]int, n)
+	for j := range ins.Subsets {
+		ins.Subsets[j] = make([]int, 0)
+	}
+
+	for i := 0; i < m; i++ {
+		k, err := s.nextCount(fmt.Sprintf("the number of columns covering row %d", i+1))
+		if err != nil {
+			return nil, err
+		}
+		for ; k > 0; k-- {
+			j, err := s.nextIndex(fmt.Sprintf("column covering row %d", i+1), n)
+			if err != nil {
+				return nil, err
+			}
+			// The rows are read in order so each subset stays sorted.
+			if subset := ins.Subsets[j]; len(subset) > 0 && subset[len(subset)-1] == i {
+				return nil, fmt.Errorf("OR-Library SCP: column %d is listed twice for row %d", j+1, i+1)
+			}
+			ins.Subsets[j] = append(ins.Subsets[j], i)
+		}
+	}
+
+	if err := s.checkEnd(); err != nil {
+		return nil, err
+	}
+	return &ins, nil
+}
+
+// WriteORLibSCP writes the instance in the OR-Library set covering format.
+// See ReadORLibSCP.
+func WriteORLibSCP(w io.Writer, ins Instance) error {
+	if err := Validate(ins); err != nil {
+		return err
+	}
+
+	elementSubsets := make([][]int, ins.ElementCount)
+	for j, subset := range ins.Subsets {
+		for _, i := range subset {
+			elementSubsets[i] = append(elementSubsets[i], j)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, " %d %d\n", ins.ElementCount, len(ins.Subsets))
+	costs := make([]string, 0, len(ins.Costs))
+	for _, c := range ins.Costs {
+		costs = append(costs, formatFloat(c))
+	}
+	writeORLibList(bw, costs)
+	for _, subsets := range elementSubsets {
+		fmt.Fprintf(bw, " %d\n", len(subsets))
+		writeORLibList(bw, oneBased(subsets))
+	}
+	return bw.Flush()
+}
+
+// ReadORLibSPPInstance reads an instance from a file in the OR-Library set
+// partitioning format, which may be compressed. See ReadORLibSPP.
+func ReadORLibSPPInstance(filename string) (*Instance, error) {
+	return readInstanceFile(filename, ReadORLibSPP)
+}
+
+// ReadORLibSPP reads an instance in the column oriented OR-Library set
+// partitioning format:
+//
+//	number of rows (m), number of columns (n)
+//	for each column j (j = 1, ..., n): the cost of the column, the number of
+//	rows that it covers followed by a list of the rows that it covers
+func ReadORLibSPP(r io.Reader) (*Instance, error) {
+	s := newORLibScanner(r, "SPP")
+	m, err := s.nextCount("the number of rows")
+	if err != nil {
+		return nil, err
+	}
+	n, err := s.nextCount("the number of columns")
+	if err != nil {
+		return nil, err
+	}
+
+	// The counts are not trusted for allocations since they may be corrupt.
+	ins := Instance{
+		ElementCount: m,
+		Subsets:      make([][]int, 0, min(n, maxPreallocation)),
+		Costs:        make([]float64, 0, min(n, maxPreallocation)),
+	}
+	for j := 0; j < n; j++ {
+		cost, err := s.nextFloat(fmt.Sprintf("the cost of column %d", j+1))
+		if err != nil {
+			return nil, err
+		}
+		k, err := s.nextCount(fmt.Sprintf("the number of rows covered by column %d", j+1))
+		if err != nil {
+			return nil, err
+		}
+		subset := make([]int, 0, min(k, maxPreallocation))
+		for ; k > 0; k-- {
+			i, err := s.nextIndex(fmt.Sprintf("row covered by column %d", j+1), m)
+			if err != nil {
+				return nil, err
+			}
+			subset = append(subset, i)
+		}
+		slices.Sort(subset)
+		for k := 1; k < len(subset); k++ {
+			if subset[k-1] == subset[k] {
+				return nil, fmt.Errorf("OR-Library SPP: row %d is listed twice for column %d", subset[k]+1, j+1)
+			}
+		}
+
+		ins.Subsets = append(ins.Subsets, subset)
+		ins.Costs = append(ins.Costs, cost)
+	}
+
+	if err := s.checkEnd(); err != nil {
+		return nil, err
+	}
+	return &ins, nil
+}
+
+// WriteORLibSPP writes the instance in the OR-Library set partitioning
+// format. See ReadORLibSPP.
+func WriteORLibSPP(w io.Writer, ins Instance) error {
+	if err := Validate(ins); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%d %d\n", ins.ElementCount, len(ins.Subsets))
+	for j, subset := range ins.Subsets {
+		fmt.Fprintf(bw, "%s %d %s\n", formatFloat(ins.Costs[j]), len(subset), strings.Join(oneBased(subset), " "))
+	}
+	return bw.Flush()
+}
+
+func oneBased(indices []int) []string {
+	s := make([]string, 0, len(indices))
+	for _, idx := range indices {
+		s = append(s, strconv.Itoa(idx+1))
+	}
+	return s
+}
+
+// writeORLibList writes the values 12 per line as in the OR-Library files.
+func writeORLibList(w *bufio.Writer, values []string) {
+	for k := 0; k < len(values); k += 12 {
+		for _, v := range values[k:min(k+12, len(values))] {
+			w.WriteString(" ")
+			w.WriteString(v)
+		}
+		w.WriteString("\n")
+	}
+}
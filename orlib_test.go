@@ -0,0 +1,93 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var orlibTestInstance = Instance{
+	ElementCount: 3,
+	Subsets:      [][]int{{0, 1}, {2}, {0, 1, 2}},
+	Costs:        []float64{1, 2, 4},
+}
+
+func TestReadORLibSCP(t *testing.T) {
+	scp := ` 3 3
+ 1 2 4
+ 2
+ 1 3
+ 2
+ 3 1
+ 2
+ 2 3
+`
+	ins, err := ReadORLibSCP(strings.NewReader(scp))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, orlibTestInstance)
+}
+
+func TestReadORLibSPP(t *testing.T) {
+	spp := `3 3
+1 2 2 1
+2 1 3
+4 3 3 1 2
+`
+	ins, err := ReadORLibSPP(strings.NewReader(spp))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, orlibTestInstance)
+}
+
+func TestORLibRoundTrip(t *testing.T) {
+	original := MakeRandomInstance(12, 50, 1000, 11)
+
+	var scp bytes.Buffer
+	assert.NilError(t, WriteORLibSCP(&scp, original))
+	ins, err := ReadORLibSCP(&scp)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, original)
+
+	var spp bytes.Buffer
+	assert.NilError(t, WriteORLibSPP(&spp, original))
+	ins, err = ReadORLibSPP(&spp)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, original)
+}
+
+func TestReadORLibErrors(t *testing.T) {
+	_, err := ReadORLibSCP(strings.NewReader("2 1\n1\n1 1\n"))
+	assert.ErrorContains(t, err, "unexpected end of file when reading the number of columns covering row 2")
+	_, err = ReadORLibSCP(strings.NewReader("1 1\n1\n1 2\n"))
+	assert.ErrorContains(t, err, "column covering row 1 2 is not in [1, 1]")
+	_, err = ReadORLibSPP(strings.NewReader("2 1\n1 2 2 2\n"))
+	assert.ErrorContains(t, err, "row 2 is listed twice for column 1")
+	_, err = ReadORLibSPP(strings.NewReader("1 1\n1 1 1\n7"))
+	assert.ErrorContains(t, err, "unexpected '7' after the end of the instance")
+
+	// Corrupt counts must neither panic nor be allocated up front.
+	_, err = ReadORLibSCP(strings.NewReader("1 -1\n"))
+	assert.ErrorContains(t, err, "the number of columns -1 is negative")
+	_, err = ReadORLibSCP(strings.NewReader("1 4000000000000\n1\n"))
+	assert.ErrorContains(t, err, "unexpected end of file when reading the cost of column 2")
+	_, err = ReadORLibSPP(strings.NewReader("1 4000000000000\n1 4000000000000 1\n"))
+	assert.ErrorContains(t, err, "unexpected end of file when reading row covered by column 1")
+}
@@ -0,0 +1,478 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package presolve reduces exact cover (set partitioning) instances before
+// they are solved.
+//
+// In the terminology of the ILP formulation, the elements are the rows and
+// the subsets are the columns of the binary matrix A in
+// min cx s.t. Ax = 1, x binary.
+// The reductions are the standard ones for set partitioning:
+//  1. An element that no subset covers makes the instance infeasible.
+//  2. An element covered by exactly one subset (singleton row) forces that
+//     subset into every exact cover. The elements of the forced subset are
+//     then covered, so they and all other subsets covering them are removed.
+//  3. Of identical subsets (duplicate columns) only the cheapest is kept.
+//  4. If every subset covering element i also covers element k (row i is
+//     dominated by row k), then subsets covering k but not i can not be in an
+//     exact cover. After removing them, the elements i and k are covered by
+//     the same subsets so k is removed.
+//  5. A subset that can be replaced by disjoint subsets with the same union
+//     and at most the same total cost (dominated column) is removed.
+//
+// The reductions are applied until none of them reduce the instance further.
+// The reductions keep at least one minimum cost exact cover, if one exists,
+// and Result.Postsolve maps exact covers of the reduced instance back to the
+// original instance.
+package presolve
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+
+	"github.com/snow-abstraction/cover"
+)
+
+// The maximum number of search nodes used to try to replace one subset
+// by a cheaper combination of subsets.
+const maxDominanceSearchNodes = 1000
+
+// Report counts what was removed by Presolve.
+type Report struct {
+	// The number of times the reductions were applied.
+	Rounds int
+	// Subsets removed because an identical and at most as expensive subset
+	// exists.
+	DuplicateSubsets int
+	// Subsets removed since they could be replaced by a cheaper or equally
+	// expensive combination of subsets.
+	DominatedSubsets int
+	// Elements removed since they are dominated by another element.
+	DominatedElements int
+	// Subsets removed due to covering a dominated element without also
+	// covering the element dominating it.
+	RowDominanceSubsets int
+	// Subsets forced into every exact cover by elements covered only by them.
+	ForcedSubsets int
+	// Elements covered by the forced subsets and therefore removed.
+	CoveredElements int
+	// Subsets removed since they cover an element that a forced subset covers.
+	ConflictingSubsets int
+	// If presolve proved that the instance has no exact cover.
+	Infeasible bool
+}
+
+// Result is a presolved instance together with what is needed to map its
+// solutions back to the original instance.
+type Result struct {
+	// The reduced instance. The names of the original instance are kept.
+	Instance cover.Instance
+	// SubsetMap[j] is the index in the original instance of subset j in the
+	// reduced instance.
+	SubsetMap []int
+	// ElementMap[i] is the index in the original instance of element i in the
+	// reduced instance.
+	ElementMap []int
+	// Indices in the original instance of the subsets that are in every
+	// exact cover.
+	Forced []int
+	// The sum of the costs of the forced subsets.
+	ForcedCost float64
+	Report     Report
+
+	originalSubsetNames []string
+}
+
+// Presolve reduces the instance. The instance is first validated with
+// cover.Validate.
+func Presolve(ins cover.Instance) (*Result, error) {
+	if err := cover.Validate(ins); err != nil {
+		return nil, err
+	}
+
+	s := newState(ins)
+	for {
+		s.report.Rounds++
+		s.rebuild()
+		if s.hasUncoverableElement() {
+			s.report.Infeasible = true
+			break
+		}
+		if s.forceSingletons() {
+			continue
+		}
+		if s.removeDuplicateSubsets() {
+			continue
+		}
+		if s.removeDominatedElements() {
+			continue
+		}
+		if s.removeDominatedSubsets() {
+			continue
+		}
+		break
+	}
+
+	result := s.result()
+	slog.Debug("presolve", "report", result.Report)
+	return result, nil
+}
+
+// Postsolve maps a solution of the reduced instance to a solution of the
+// original instance by mapping the indices and adding the forced subsets.
+// If sol is not an exact cover, the zero value is returned since the
+// original instance then has no exact cover either.
+func (r *Result) Postsolve(sol cover.SubsetsEval) cover.SubsetsEval {
+	if !sol.ExactlyCovered {
+		return cover.SubsetsEval{}
+	}
+
+	indices := make([]int, 0, len(sol.SubsetsIndices)+len(r.Forced))
+	for _, j := range sol.SubsetsIndices {
+		indices = append(indices, r.SubsetMap[j])
+	}
+	indices = append(indices, r.Forced...)
+	slices.Sort(indices)
+
+	result := cover.SubsetsEval{
+		SubsetsIndices: indices,
+		ExactlyCovered: true,
+		Cost:           sol.Cost + r.ForcedCost,
+		Optimal:        sol.Optimal,
+	}
+	if r.originalSubsetNames != nil {
+		result.SubsetNames = make([]string, 0, len(indices))
+		for _, j := range indices {
+			result.SubsetNames = append(result.SubsetNames, r.originalSubsetNames[j])
+		}
+	}
+	return result
+}
+
+type state struct {
+	ins cover.Instance
+
+	activeSubsets  []bool
+	activeElements []bool
+	// The active elements of each active subset.
+	subsetElements [][]int
+	// The active subsets covering each active element.
+	elementSubsets [][]int
+
+	forced []int
+	report Report
+}
+
+func newState(ins cover.Instance) *state {
+	s := &state{
+		ins:            ins,
+		activeSubsets:  make([]bool, len(ins.Subsets)),
+		activeElements: make([]bool, ins.ElementCount),
+		subsetElements: make([][]int, len(ins.Subsets)),
+		elementSubsets: make([][]int, ins.ElementCount),
+	}
+	for j, subset := range ins.Subsets {
+		s.activeSubsets[j] = true
+		s.subsetElements[j] = slices.Clone(subset)
+	}
+	for i := range s.activeElements {
+		s.activeElements[i] = true
+	}
+	return s
+}
+
+// rebuild removes the inactive elements from the subsets and
+// recalculates which subsets cover each element.
+func (s *state) rebuild() {
+	for i := range s.elementSubsets {
+		s.elementSubsets[i] = s.elementSubsets[i][:0]
+	}
+	for j, elements := range s.subsetElements {
+		if !s.activeSubsets[j] {
+			s.subsetElements[j] = nil
+			continue
+		}
+		elements = slices.DeleteFunc(elements, func(i int) bool { return !s.activeElements[i] })
+		s.subsetElements[j] = elements
+		for _, i := range elements {
+			s.elementSubsets[i] = append(s.elementSubsets[i], j)
+		}
+	}
+}
+
+func (s *state) hasUncoverableElement() bool {
+	for i, subsets := range s.elementSubsets {
+		if s.activeElements[i] && len(subsets) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// forceSingletons forces the subsets that are the only subset covering some
+// element. It returns true if any subset was forced.
+func (s *state) forceSingletons() bool {
+	changed := false
+	for i, subsets := range s.elementSubsets {
+		if !s.activeElements[i] || len(subsets) != 1 || !s.activeSubsets[subsets[0]] {
+			// If the only subset was removed as conflicting in this pass, the
+			// element can not be covered which is detected in the next round.
+			continue
+		}
+
+		j := subsets[0]
+		s.activeSubsets[j] = false
+		s.forced = append(s.forced, j)
+		s.report.ForcedSubsets++
+		for _, covered := range s.subsetElements[j] {
+			s.activeElements[covered] = false
+			s.report.CoveredElements++
+			for _, k := range s.elementSubsets[covered] {
+				if k != j && s.activeSubsets[k] {
+					s.activeSubsets[k] = false
+					s.report.ConflictingSubsets++
+				}
+			}
+		}
+		changed = true
+	}
+	return changed
+}
+
+// removeDuplicateSubsets keeps only the cheapest of subsets with the same
+// active elements. For equal costs, the one with the lowest index is kept.
+func (s *state) removeDuplicateSubsets() bool {
+	active := make([]int, 0, len(s.subsetElements))
+	for j := range s.subsetElements {
+		if s.activeSubsets[j] {
+			active = append(active, j)
+		}
+	}
+
+	slices.SortFunc(active, func(lhs, rhs int) int {
+		if c := slices.Compare(s.subsetElements[lhs], s.subsetElements[rhs]); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(s.ins.Costs[lhs], s.ins.Costs[rhs]); c != 0 {
+			return c
+		}
+		return cmp.Compare(lhs, rhs)
+	})
+
+	changed := false
+	for k := 1; k < len(active); k++ {
+		if slices.Equal(s.subsetElements[active[k-1]], s.subsetElements[active[k]]) {
+			// active[k-1] might have been removed in the previous
+			// iteration but then the subset before it is identical.
+			s.activeSubsets[active[k]] = false
+			s.report.DuplicateSubsets++
+			changed = true
+		}
+	}
+	return changed
+}
+
+// isActiveSubsetOf reports whether the active subsets in the sorted x are
+// all in the sorted y.
+func (s *state) isActiveSubsetOf(x, y []int) bool {
+	k := 0
+	for _, j := range x {
+		if !s.activeSubsets[j] {
+			continue
+		}
+		for k < len(y) && y[k] < j {
+			k++
+		}
+		if k == len(y) || y[k] != j {
+			return false
+		}
+	}
+	return true
+}
+
+// removeDominatedElements removes elements k that are dominated by another
+// element i, i.e. every subset covering i also covers k.
+func (s *state) removeDominatedElements() bool {
+	changed := false
+	for i, subsets := range s.elementSubsets {
+		if !s.activeElements[i] {
+			continue
+		}
+
+		// Any element dominating i is covered by all subsets covering i so
+		// only the elements of one of them need to be checked.
+		first := slices.IndexFunc(subsets, func(j int) bool { return s.activeSubsets[j] })
+		if first == -1 {
+			continue
+		}
+		for _, k := range s.subsetElements[subsets[first]] {
+			if k == i || !s.activeElements[k] || !s.isActiveSubsetOf(subsets, s.elementSubsets[k]) {
+				continue
+			}
+
+			for _, j := range s.elementSubsets[k] {
+				if s.activeSubsets[j] && !slices.Contains(subsets, j) {
+					s.activeSubsets[j] = false
+					s.report.RowDominanceSubsets++
+				}
+			}
+			s.activeElements[k] = false
+			s.report.DominatedElements++
+			changed = true
+		}
+	}
+	return changed
+}
+
+// removeDominatedSubsets removes subsets which can be replaced by other
+// subsets partitioning the subset's elements at no greater cost.
+func (s *state) removeDominatedSubsets() bool {
+	changed := false
+	inSubset := make([]bool, s.ins.ElementCount)
+	covered := make([]bool, s.ins.ElementCount)
+	for j, elements := range s.subsetElements {
+		if !s.activeSubsets[j] || len(elements) < 2 {
+			continue
+		}
+
+		for _, i := range elements {
+			inSubset[i] = true
+		}
+		if s.canBePartitioned(j, inSubset, covered) {
+			s.activeSubsets[j] = false
+			s.report.DominatedSubsets++
+			changed = true
+		}
+		for _, i := range elements {
+			inSubset[i] = false
+		}
+	}
+	return changed
+}
+
+// canBePartitioned searches for other subsets, that only contain elements of
+// subset j, that are disjoint, have the same union as subset j and have a
+// total cost no greater than that of subset j. The search is limited to
+// maxDominanceSearchNodes nodes.
+func (s *state) canBePartitioned(j int, inSubset []bool, covered []bool) bool {
+	elements := s.subsetElements[j]
+	budget := s.ins.Costs[j]
+	nodes := 0
+
+	var search func(cost float64) bool
+	search = func(cost float64) bool {
+		nodes++
+		if nodes > maxDominanceSearchNodes {
+			return false
+		}
+
+		next := slices.IndexFunc(elements, func(i int) bool { return !covered[i] })
+		if next == -1 {
+			return true
+		}
+		for _, k := range s.elementSubsets[elements[next]] {
+			if k == j || !s.activeSubsets[k] || cost+s.ins.Costs[k] > budget {
+				continue
+			}
+			fits := true
+			for _, i := range s.subsetElements[k] {
+				if !inSubset[i] || covered[i] {
+					fits = false
+					break
+				}
+			}
+			if !fits {
+				continue
+			}
+
+			for _, i := range s.subsetElements[k] {
+				covered[i] = true
+			}
+			found := search(cost + s.ins.Costs[k])
+			for _, i := range s.subsetElements[k] {
+				covered[i] = false
+			}
+			if found {
+				return true
+			}
+		}
+		return false
+	}
+
+	return search(0)
+}
+
+// result makes the reduced instance from the state.
+func (s *state) result() *Result {
+	r := &Result{
+		SubsetMap:           make([]int, 0),
+		ElementMap:          make([]int, 0),
+		Forced:              slices.Sorted(slices.Values(s.forced)),
+		Report:              s.report,
+		originalSubsetNames: s.ins.SubsetNames,
+	}
+	for _, j := range r.Forced {
+		r.ForcedCost += s.ins.Costs[j]
+	}
+
+	newElementIndex := make([]int, s.ins.ElementCount)
+	for i, active := range s.activeElements {
+		if active {
+			newElementIndex[i] = len(r.ElementMap)
+			r.ElementMap = append(r.ElementMap, i)
+		}
+	}
+
+	ins := cover.Instance{
+		ElementCount: len(r.ElementMap),
+		Subsets:      make([][]int, 0),
+		Costs:        make([]float64, 0),
+	}
+	for j, active := range s.activeSubsets {
+		if !active {
+			continue
+		}
+		// rebuild has not been called after the last reductions, if any, so
+		// filter the elements again.
+		subset := make([]int, 0, len(s.subsetElements[j]))
+		for _, i := range s.subsetElements[j] {
+			if s.activeElements[i] {
+				subset = append(subset, newElementIndex[i])
+			}
+		}
+		r.SubsetMap = append(r.SubsetMap, j)
+		ins.Subsets = append(ins.Subsets, subset)
+		ins.Costs = append(ins.Costs, s.ins.Costs[j])
+	}
+
+	if s.ins.ElementNames != nil {
+		ins.ElementNames = make([]string, 0, len(r.ElementMap))
+		for _, i := range r.ElementMap {
+			ins.ElementNames = append(ins.ElementNames, s.ins.ElementNames[i])
+		}
+	}
+	if s.ins.SubsetNames != nil {
+		ins.SubsetNames = make([]string, 0, len(r.SubsetMap))
+		for _, j := range r.SubsetMap {
+			ins.SubsetNames = append(ins.SubsetNames, s.ins.SubsetNames[j])
+		}
+	}
+
+	r.Instance = ins
+	return r
+}
@@ -0,0 +1,161 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package presolve
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/snow-abstraction/cover"
+	"github.com/snow-abstraction/cover/solvers"
+	"gotest.tools/v3/assert"
+)
+
+func TestForcedAndConflictingSubsets(t *testing.T) {
+	// Element 2 is only covered by {1, 2} which forces it and then {0, 1}
+	// conflicts with it.
+	ins := cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1}, {1, 2}, {0}},
+		Costs:        []float64{1, 2, 3},
+		SubsetNames:  []string{"a", "b", "c"},
+	}
+	r, err := Presolve(ins)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r.Forced, []int{1, 2})
+	assert.Equal(t, r.ForcedCost, 5.0)
+	assert.Equal(t, r.Instance.ElementCount, 0)
+	assert.Equal(t, len(r.Instance.Subsets), 0)
+	assert.Equal(t, r.Report.ForcedSubsets, 2)
+	assert.Equal(t, r.Report.ConflictingSubsets, 1)
+	assert.Assert(t, !r.Report.Infeasible)
+
+	sol, err := solvers.SolveByBranchAndBound(r.Instance)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r.Postsolve(sol), cover.SubsetsEval{
+		SubsetsIndices: []int{1, 2},
+		ExactlyCovered: true,
+		Cost:           5,
+		Optimal:        true,
+		SubsetNames:    []string{"b", "c"},
+	})
+}
+
+func TestDominatedElements(t *testing.T) {
+	// Every subset covering element 0 covers element 1, so {1, 2} can not be
+	// in an exact cover and element 1 can be removed.
+	ins := cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1}, {0, 1, 2}, {1, 2}, {2}},
+		Costs:        []float64{1, 5, 1, 1},
+	}
+	r, err := Presolve(ins)
+	assert.NilError(t, err)
+	assert.Equal(t, r.Report.DominatedElements, 1)
+	assert.Equal(t, r.Report.RowDominanceSubsets, 1)
+	// {0} {0, 2} {2} remain where {0, 2} is dominated by {0} + {2}. Then
+	// the reduced instance is solved by forcing.
+	assert.Equal(t, r.Report.DominatedSubsets, 1)
+	assert.DeepEqual(t, r.Forced, []int{0, 3})
+}
+
+func TestDominatedSubsets(t *testing.T) {
+	ins := cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1, 2}, {0, 1}, {2}, {0}, {1}, {1, 2}},
+		Costs:        []float64{10, 3, 4, 2, 2, 9},
+	}
+	r, err := Presolve(ins)
+	assert.NilError(t, err)
+	// {0, 1, 2} and {1, 2} are dominated and then {2} is forced.
+	assert.Equal(t, r.Report.DominatedSubsets, 2)
+	assert.DeepEqual(t, r.Forced, []int{2})
+	assert.DeepEqual(t, r.SubsetMap, []int{1, 3, 4})
+}
+
+func TestDuplicateSubsets(t *testing.T) {
+	ins := cover.Instance{
+		ElementCount: 2,
+		Subsets:      [][]int{{0, 1}, {0, 1}, {0}, {1}, {0}, {1}},
+		Costs:        []float64{7, 4, 3, 3, 2, 4},
+	}
+	r, err := Presolve(ins)
+	assert.NilError(t, err)
+	assert.Equal(t, r.Report.DuplicateSubsets, 3)
+	assert.DeepEqual(t, r.SubsetMap, []int{1, 3, 4})
+}
+
+func TestInfeasible(t *testing.T) {
+	ins := cover.Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{0, 1}, {1, 2}},
+		Costs:        []float64{1, 1},
+	}
+	r, err := Presolve(ins)
+	assert.NilError(t, err)
+	assert.Assert(t, r.Report.Infeasible)
+	sol, err := solvers.SolveByBranchAndBound(r.Instance)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, r.Postsolve(sol), cover.SubsetsEval{})
+}
+
+func TestPresolveOnTinyInstances(t *testing.T) {
+	var specifications []cover.TestInstanceSpecification
+	b, err := os.ReadFile("../testdata/tiny_instance_specifications.json")
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(b, &specifications))
+
+	for _, spec := range specifications {
+		t.Run(fmt.Sprintf("instance %+v", spec), func(t *testing.T) {
+			t.Parallel()
+			pythonResultBytes, err := os.ReadFile(filepath.Join("..", spec.PythonSolutionPath))
+			assert.NilError(t, err)
+			var pythonResult map[string]interface{}
+			assert.NilError(t, json.Unmarshal(pythonResultBytes, &pythonResult))
+
+			ins, err := cover.ReadJsonInstance(filepath.Join("..", spec.InstancePath))
+			assert.NilError(t, err)
+			r, err := Presolve(*ins)
+			assert.NilError(t, err)
+			reduced, err := solvers.SolveByBruteForce(r.Instance)
+			assert.NilError(t, err)
+			result := r.Postsolve(reduced)
+
+			if !result.ExactlyCovered {
+				assert.Equal(t, "infeasible", pythonResult["status"].(string))
+				return
+			}
+			assert.Equal(t, "optimal", pythonResult["status"].(string))
+			assert.Assert(t, math.Abs(result.Cost-pythonResult["cost"].(float64)) < 1e-9)
+
+			counts := make([]int, ins.ElementCount)
+			for _, j := range result.SubsetsIndices {
+				for _, i := range ins.Subsets[j] {
+					counts[i]++
+				}
+			}
+			for i, c := range counts {
+				assert.Equal(t, c, 1, "element %d", i)
+			}
+		})
+	}
+}
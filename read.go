@@ -0,0 +1,201 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format is a file format for instances.
+type Format int
+
+const (
+	FormatUnknown Format = iota
+	FormatJSON
+	FormatMPS
+	FormatORLibSCP // OR-Library set covering format, see ReadORLibSCP
+	FormatORLibSPP // OR-Library set partitioning format, see ReadORLibSPP
+	FormatBinary   // see binary.go
+	FormatLP       // CPLEX LP format, which can only be written
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "JSON"
+	case FormatMPS:
+		return "MPS"
+	case FormatORLibSCP:
+		return "OR-Library SCP"
+	case FormatORLibSPP:
+		return "OR-Library SPP"
+	case FormatBinary:
+		return "binary"
+	case FormatLP:
+		return "LP"
+	}
+	return "unknown"
+}
+
+// compressionExtensions are the extensions of the supported compressions.
+var compressionExtensions = []string{".gz", ".bz2"}
+
+// FormatFromFilename returns the format implied by the file extension,
+// ignoring case and a compression extension (.gz or .bz2), e.g.
+// "instance.json.gz" is FormatJSON. The extensions are .json, .mps, .scp,
+// .spp, .cover (binary) and .lp. OR-Library .txt files are recognized by their names starting with scp
+// or spp, e.g. scp41.txt and sppnw01.txt. FormatUnknown is returned if the
+// extension is not recognized.
+func FormatFromFilename(filename string) Format {
+	base := strings.ToLower(filepath.Base(filename))
+	for _, ext := range compressionExtensions {
+		base = strings.TrimSuffix(base, ext)
+	}
+
+	switch filepath.Ext(base) {
+	case ".json":
+		return FormatJSON
+	case ".mps":
+		return FormatMPS
+	case ".scp":
+		return FormatORLibSCP
+	case ".spp":
+		return FormatORLibSPP
+	case ".cover":
+		return FormatBinary
+	case ".lp":
+		return FormatLP
+	case ".txt":
+		if strings.HasPrefix(base, "scp") {
+			return FormatORLibSCP
+		}
+		if strings.HasPrefix(base, "spp") {
+			return FormatORLibSPP
+		}
+	}
+	return FormatUnknown
+}
+
+// ReadInstance reads an instance from the file at path. gzip and bzip2
+// compressed files are decompressed, which is detected by their content. The
+// format is determined by the binary format's magic bytes, then by
+// FormatFromFilename and otherwise by the content: JSON starts with '{' and
+// MPS with a comment or a NAME or ROWS section. The
+// OR-Library formats can not be told apart by their content so they must
+// be named accordingly.
+func ReadInstance(path string) (*Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r, err := decompress(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	format := FormatFromFilename(path)
+	if magic, _ := r.Peek(len(binaryMagic)); bytes.Equal(magic, binaryMagic) {
+		format = FormatBinary
+	}
+	if format == FormatUnknown {
+		format = sniffFormat(r)
+	}
+	if format == FormatUnknown {
+		return nil, fmt.Errorf(
+			"unable to determine the format of %s. The file should end in .json, .mps, .scp or .spp", path)
+	}
+	return ReadFormat(r, format)
+}
+
+// ReadFormat reads an instance in the format from r, which is not
+// decompressed.
+func ReadFormat(r io.Reader, format Format) (*Instance, error) {
+	switch format {
+	case FormatJSON:
+		return ReadJSON(r)
+	case FormatMPS:
+		return ReadMPS(r)
+	case FormatORLibSCP:
+		return ReadORLibSCP(r)
+	case FormatORLibSPP:
+		return ReadORLibSPP(r)
+	case FormatBinary:
+		return ReadBinary(r)
+	}
+	return nil, fmt.Errorf("unable to read the format %v", format)
+}
+
+// readInstanceFile reads the file, which may be compressed, using read.
+func readInstanceFile(filename string, read func(io.Reader) (*Instance, error)) (*Instance, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r, err := decompress(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return read(r)
+}
+
+// decompress returns a reader of the decompressed content of r if r is gzip
+// or bzip2 compressed and otherwise a reader of r.
+func decompress(r io.Reader) (*bufio.Reader, error) {
+	br := bufio.NewReader(r)
+	// Peek returns an error if there are fewer bytes, which just means that
+	// the content is not compressed.
+	magic, _ := br.Peek(3)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return bufio.NewReader(gr), nil
+	case bytes.Equal(magic, []byte("BZh")):
+		return bufio.NewReader(bzip2.NewReader(br)), nil
+	}
+	return br, nil
+}
+
+// sniffFormat guesses the format by the start of the content.
+func sniffFormat(r *bufio.Reader) Format {
+	start, _ := r.Peek(512)
+	start = bytes.TrimLeft(start, " \t\r\n")
+	switch {
+	case bytes.HasPrefix(start, []byte("{")):
+		return FormatJSON
+	case bytes.HasPrefix(start, []byte("*")),
+		bytes.HasPrefix(start, []byte("NAME")),
+		bytes.HasPrefix(start, []byte("ROWS")):
+		return FormatMPS
+	}
+	return FormatUnknown
+}
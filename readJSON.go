@@ -0,0 +1,109 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadJSON reads an instance in the JSON format written by encoding/json for
+// Instance from r. As with encoding/json, the keys are matched case
+// insensitively and unknown keys are ignored.
+//
+// The instance is decoded while it is read, one subset at a time, so the
+// whole JSON document is never in memory, unlike with json.Unmarshal.
+func ReadJSON(r io.Reader) (*Instance, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var ins Instance
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("JSON instance: %w", err)
+		}
+		key, ok := t.(string)
+		if !ok {
+			return nil, fmt.Errorf("JSON instance: expected an object key but got %v", t)
+		}
+
+		switch strings.ToLower(key) {
+		case "elementcount":
+			err = dec.Decode(&ins.ElementCount)
+		case "subsets":
+			ins.Subsets, err = decodeSubsets(dec)
+		case "costs":
+			err = dec.Decode(&ins.Costs)
+		case "elementnames":
+			err = dec.Decode(&ins.ElementNames)
+		case "subsetnames":
+			err = dec.Decode(&ins.SubsetNames)
+		default:
+			var ignored json.RawMessage
+			err = dec.Decode(&ignored)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("JSON instance: unable to read %s: %w", key, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return &ins, nil
+}
+
+// decodeSubsets decodes an array of subsets one subset at a time.
+func decodeSubsets(dec *json.Decoder) ([][]int, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, nil
+	}
+	if t != json.Delim('[') {
+		return nil, fmt.Errorf("expected an array but got %v", t)
+	}
+
+	subsets := make([][]int, 0)
+	for dec.More() {
+		var subset []int
+		if err := dec.Decode(&subset); err != nil {
+			return nil, err
+		}
+		subsets = append(subsets, subset)
+	}
+	return subsets, expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	t, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("JSON instance: %w", err)
+	}
+	if t != delim {
+		return fmt.Errorf("JSON instance: expected '%v' but got %v", delim, t)
+	}
+	return nil
+}
@@ -19,10 +19,12 @@ package cover
 
 import (
 	"bufio"
-	"errors"
+	"cmp"
 	"fmt"
+	"io"
 	"log/slog"
-	"os"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -35,207 +37,602 @@ const (
 	MPS_SECTION_RHS
 	MPS_SECTION_BOUNDS
 	MPS_SECTION_ENDATA
+	MPS_SECTION_RANGES
+	MPS_SECTION_OBJSENSE
+	MPS_SECTION_OBJNAME
 )
 
-// ReadMPSInstance reads an exact set covering problem from a MPS file.
-//
-// ReadMPSInstance has neither been tested systematically or programmatically.
-// It has been tested successfully on a few exact cover (i.e. setting partition)
-// files from the miplib2003 and miplip2010 problem collections.
+// MPSError is an error found when reading a MPS file.
+type MPSError struct {
+	// The line number (starting from 1) of the offending line or 0 if the
+	// error is not due to a single line.
+	Line int
+	Msg  string
+}
+
+func (e *MPSError) Error() string {
+	if e.Line == 0 {
+		return "MPS: " + e.Msg
+	}
+	return fmt.Sprintf("MPS line %d: %s", e.Line, e.Msg)
+}
+
+func mpsErrorf(line int, format string, a ...any) error {
+	return &MPSError{line, fmt.Sprintf(format, a...)}
+}
+
+// ReadMPSInstance reads an exact set covering problem from a MPS file,
+// which may be compressed. See ReadMPS.
 func ReadMPSInstance(filename string) (*Instance, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
+	return readInstanceFile(filename, ReadMPS)
+}
+
+func parseMPSSection(s string) (int, error) {
+	switch strings.Fields(s)[0] {
+	case "NAME":
+		return MPS_SECTION_NAME, nil
+	case "ROWS":
+		return MPS_SECTION_ROWS, nil
+	case "COLUMNS":
+		return MPS_SECTION_COLUMNS, nil
+	case "RHS":
+		return MPS_SECTION_RHS, nil
+	case "RANGES":
+		return MPS_SECTION_RANGES, nil
+	case "BOUNDS":
+		return MPS_SECTION_BOUNDS, nil
+	case "OBJSENSE":
+		return MPS_SECTION_OBJSENSE, nil
+	case "OBJNAME":
+		return MPS_SECTION_OBJNAME, nil
+	case "ENDATA":
+		return MPS_SECTION_ENDATA, nil
 	}
-	defer file.Close()
+	return MPS_SECTION_NOT_SET, fmt.Errorf("unsupported MPS section '%s'", s)
+}
 
-	ins := Instance{}
-	scanner := bufio.NewScanner(file)
+// The positions (0-indexed, end exclusive) of the fields in fixed MPS.
+var mpsFixedFields = [][2]int{{1, 3}, {4, 12}, {14, 22}, {24, 36}, {39, 47}, {49, 61}}
 
-	prefix := "MPS reader"
-	currentSection := MPS_SECTION_NOT_SET
-	foundCostRow := false
-	rhsCount := 0
-	upperBoundCount := 0
-	rows := make(map[string]int, 0)    // row name to row/element index
-	columns := make(map[string]int, 0) // column name to column/subset index
-	for scanner.Scan() {
-		s := scanner.Text()
-		if s == "" {
+// splitFixedMPS splits a line into the fixed MPS fields. The first field
+// (e.g. the row sense) is only included if includeFirst is true. Trailing
+// empty fields are dropped.
+func splitFixedMPS(s string, includeFirst bool) []string {
+	fields := make([]string, 0, len(mpsFixedFields))
+	for k, pos := range mpsFixedFields {
+		if k == 0 && !includeFirst {
 			continue
-		} else if strings.HasPrefix(s, "*") {
-			slog.Debug(prefix, "comment", s)
+		}
+		if pos[0] >= len(s) {
+			break
+		}
+		fields = append(fields, strings.TrimSpace(s[pos[0]:min(pos[1], len(s))]))
+	}
+	for len(fields) > 0 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	return fields
+}
+
+// mpsCoefficient is a value for a row and column pair from the COLUMNS section.
+type mpsCoefficient struct {
+	row   string
+	value float64
+	line  int
+}
+
+// mpsRowValue is a value for a row from the RHS or RANGES section.
+type mpsRowValue struct {
+	value float64
+	line  int
+}
+
+type mpsColumn struct {
+	name         string
+	coefficients []mpsCoefficient
+	integer      bool
+	lower        float64
+	upper        float64
+	line         int // first line of the column
+}
+
+type mpsBound struct {
+	kind   string
+	column string
+	value  float64
+	line   int
+}
+
+// mpsReader collects the content of the sections. Since the sections can be
+// in any order, names are only resolved after the whole file has been read.
+type mpsReader struct {
+	line    int
+	section int
+
+	objectiveName string // from OBJNAME
+	maximize      bool
+
+	freeRows map[string]int // N rows to their line
+	firstN   string
+	rows     map[string]int // E rows to their element index
+	rowNames []string
+
+	columns     map[string]int
+	columnsList []*mpsColumn
+	inInteger   bool
+
+	rhs    map[string]mpsRowValue
+	ranges map[string]mpsRowValue
+	bounds []mpsBound
+}
+
+// ReadMPS reads an exact set covering problem in the MPS format from r.
+// The row and column names are kept as the element and subset names.
+//
+// Both the fixed and free MPS formats are supported and the sections may
+// be in any order. The file must describe a binary set partitioning
+// problem, i.e.
+//
+//	min cx s.t. Ax = 1, x binary
+//
+// where all the nonzero coefficients of A are 1. Specifically:
+//   - The objective is the row named by an OBJNAME section or otherwise the
+//     first N row. Other N rows are ignored. The objective sense must be
+//     minimization.
+//   - All other rows must be E rows with right-hand side 1. RANGES are only
+//     allowed if they are 0.
+//   - The columns must be integer, either by being between 'MARKER'
+//     'INTORG' and 'MARKER' 'INTEND' lines or by BV, LI or UI bounds. The
+//     lower bounds must be 0 and the upper bounds must be at least 1. A
+//     column outside the markers with the bounds [0, 1], e.g. from an UP
+//     bound of 1, is also taken to be binary.
+//   - Every column must be in at least one E row. Such columns are
+//     rejected instead of dropped so that the subset indices are the
+//     column indices in the file.
+//
+// A data line is split into fields by white space. If that does not
+// result in a valid number of fields for the section, the fixed MPS field
+// positions are used instead. Thus names with spaces are only supported
+// when the number of fields is not valid otherwise.
+//
+// The file is read line by line but the sections are kept in memory until
+// ENDATA since they may be in any order.
+func ReadMPS(r io.Reader) (*Instance, error) {
+	p := mpsReader{
+		freeRows: make(map[string]int),
+		rows:     make(map[string]int),
+		columns:  make(map[string]int),
+		rhs:      make(map[string]mpsRowValue),
+		ranges:   make(map[string]mpsRowValue),
+	}
+
+	scanner := bufio.NewScanner(r)
+	// Lines in free MPS may be long.
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	ended := false
+	for scanner.Scan() {
+		p.line++
+		s := strings.TrimRight(scanner.Text(), " \t\r")
+		if s == "" || strings.HasPrefix(s, "*") || strings.HasPrefix(s, "$") {
 			continue
 		}
 
-		newSection := !strings.HasPrefix(s, " ") && !strings.HasPrefix(s, "\t")
-		if newSection {
-			slog.Info(prefix, "start section", s)
-			currentSection, err = parseMPSSection(s)
-			if err != nil {
-				return nil, fmt.Errorf("unsupported MPS section '%s'", s)
+		if !strings.HasPrefix(s, " ") && !strings.HasPrefix(s, "\t") {
+			if err := p.startSection(s); err != nil {
+				return nil, err
 			}
-			if currentSection == MPS_SECTION_ENDATA {
+			if p.section == MPS_SECTION_ENDATA {
+				ended = true
 				break
 			}
 			continue
 		}
 
-		switch currentSection {
-		case MPS_SECTION_ROWS:
-			if strings.Contains(s, "N") && strings.Contains(s, "COST") {
-				foundCostRow = true
-				continue
-			}
-			fields := strings.Fields(s)
-			if len(fields) != 2 {
-				return nil, fmt.Errorf("ROW entry should contain two fields but found '%s'", s)
-			}
-			if fields[0] != "E" {
-				return nil, fmt.Errorf("only ROW sense E supported but found sense '%s'", fields[0])
-			}
-			if _, found := rows[fields[1]]; found {
-				return nil, fmt.Errorf("ROW name '%s' duplicated", fields[0])
-			}
-			rows[fields[1]] = len(rows)
-			ins.ElementCount++
-		case MPS_SECTION_COLUMNS:
-			if !foundCostRow {
-				return nil, fmt.Errorf("expected cost row to be found before COLUMN section")
+		if err := p.readDataLine(s); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !ended {
+		return nil, mpsErrorf(0, "missing ENDATA")
+	}
 
-			}
-			if strings.Contains(s, "MARKER") {
+	return p.makeInstance()
+}
 
-				continue
-			}
-			fields := strings.Fields(s)
-			if len(fields) == 0 {
-				return nil, fmt.Errorf("expected column name in COLUMN entry but found '%s'", s)
-			}
-			if len(fields) != 1 && len(fields) != 3 && len(fields) != 5 {
-				return nil, fmt.Errorf(
-					"expected COLUMN entry a column name with 0, 1 or 2 row entries but found '%s'", s)
+func (p *mpsReader) startSection(s string) error {
+	section, err := parseMPSSection(s)
+	if err != nil {
+		switch name := strings.Fields(s)[0]; name {
+		case "SOS", "QUADOBJ", "QMATRIX", "QSECTION", "QCMATRIX", "CSECTION", "INDICATORS", "USERCUTS", "LAZYCONS":
+			return mpsErrorf(p.line,
+				"section %s is not supported since the model is then not a set partitioning problem", name)
+		}
+		return mpsErrorf(p.line, "unsupported section '%s'", s)
+	}
+	slog.Debug("MPS reader", "start section", s, "line", p.line)
+	p.section = section
 
-			}
-			colIdx, found := columns[fields[0]]
-			if !found {
-				colIdx = len(columns)
-				columns[fields[0]] = colIdx
-			}
+	// Some sections may have their value on the same line in free MPS.
+	fields := strings.Fields(s)
+	if len(fields) > 1 {
+		switch section {
+		case MPS_SECTION_OBJSENSE, MPS_SECTION_OBJNAME:
+			return p.readDataLine(strings.Join(fields[1:], " "))
+		}
+	}
+	return nil
+}
 
-			for len(ins.Subsets) <= colIdx {
-				ins.Subsets = append(ins.Subsets, make([]int, 0))
-			}
-			for len(ins.Costs) <= colIdx {
-				ins.Costs = append(ins.Costs, 0)
-			}
+func (p *mpsReader) readDataLine(s string) error {
+	switch p.section {
+	case MPS_SECTION_ROWS:
+		return p.readRow(s)
+	case MPS_SECTION_COLUMNS:
+		return p.readColumn(s)
+	case MPS_SECTION_RHS:
+		return p.readRowValues(s, "RHS", p.rhs)
+	case MPS_SECTION_RANGES:
+		return p.readRowValues(s, "RANGES", p.ranges)
+	case MPS_SECTION_BOUNDS:
+		return p.readBound(s)
+	case MPS_SECTION_OBJSENSE:
+		switch strings.TrimSpace(s) {
+		case "MIN", "MINIMIZE":
+			p.maximize = false
+		case "MAX", "MAXIMIZE":
+			p.maximize = true
+		default:
+			return mpsErrorf(p.line, "unknown objective sense '%s'", strings.TrimSpace(s))
+		}
+		return nil
+	case MPS_SECTION_OBJNAME:
+		p.objectiveName = strings.TrimSpace(s)
+		return nil
+	case MPS_SECTION_NOT_SET, MPS_SECTION_NAME:
+		return mpsErrorf(p.line, "data line outside of a section")
+	}
+	return mpsErrorf(p.line, "mps section processing error")
+}
 
-			for i := 1; i < len(fields); i = i + 2 {
-				if fields[i] == "COST" {
-					cost, err := strconv.ParseFloat(fields[i+1], 64)
-					if err != nil {
-						return nil, fmt.Errorf("unable to parse cost '%s' from COLUMN entry '%s'", fields[i+1], s)
-					}
-
-					ins.Costs[colIdx] = cost
-				} else {
-					rowIdx, found := rows[fields[i]]
-					if !found {
-						return nil, fmt.Errorf("unknown row '%s' in COLUMN entry '%s'", fields[i], s)
-					}
-					one, err := strconv.ParseFloat(fields[i+1], 64)
-					if err != nil {
-						return nil, fmt.Errorf("unable to parse expected '1' from '%s' from COLUMN entry '%s'", fields[i+1], s)
-					}
-					if one != 1.0 {
-						return nil, fmt.Errorf("expect all constraint values to be exactly 1.0 in COLUMN entry '%s'", s)
-					}
-					ins.Subsets[colIdx] = append(ins.Subsets[colIdx], rowIdx)
-				}
-			}
-		case MPS_SECTION_RHS:
-			fields := strings.Fields(s)
-			if len(fields) != 3 && len(fields) != 5 {
-				return nil, fmt.Errorf("expect RHS entry to contain 1 or 2 entries '%s'", s)
-			}
-			for i := 1; i < len(fields); i = i + 2 {
+func (p *mpsReader) parseNumber(s string) (float64, error) {
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, mpsErrorf(p.line, "unable to parse number '%s'", s)
+	}
+	return x, nil
+}
 
-				_, found := rows[fields[i]]
-				if !found {
-					return nil, fmt.Errorf("unknown row '%s' in RHS entry '%s'", fields[i], s)
-				}
-				rhs, err := strconv.ParseFloat(fields[i+1], 64)
-				if err != nil {
-					return nil, fmt.Errorf("unable to parse rhs '%s' from RHS entry '%s'", fields[i+1], s)
-				}
-				if rhs != 1.0 {
-					return nil, fmt.Errorf("expect all rhs values to be exactly 1.0 in RHS entry '%s'", s)
-				}
-				rhsCount++
-			}
-		case MPS_SECTION_BOUNDS:
+func (p *mpsReader) readRow(s string) error {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		fields = splitFixedMPS(s, true)
+	}
+	if len(fields) != 2 {
+		return mpsErrorf(p.line, "ROWS entry should contain a sense and a name but found '%s'", s)
+	}
 
-			fields := strings.Fields(s)
-			errMsg := fmt.Sprintf("expect UP BND followed by column name and 1 for all BOUND entries but found '%s'", s)
-			if len(fields) != 4 {
-				return nil, errors.New(errMsg)
-			}
-			up := strings.ToUpper(fields[0])
-			if fields[0] != up {
-				return nil, errors.New(errMsg)
-			}
-			bnd := strings.ToUpper(fields[1])
-			if bnd != "BND" {
-				return nil, errors.New(errMsg)
-			}
-			_, found := columns[fields[2]]
-			if !found {
-				return nil, fmt.Errorf("unknown column '%s' in BOUNDS entry '%s'", fields[2], s)
-			}
-			upperBound, err := strconv.ParseFloat(fields[3], 64)
-			if err != nil {
-				return nil, fmt.Errorf(
-					"unable to parse upper bound '%s' for column '%s' from BOUNDS entry '%s'",
-					fields[3], fields[2], s)
-			}
-			if upperBound != 1.0 {
-				return nil, fmt.Errorf("expect all upper bounds values to be exactly 1.0 in RHS entry '%s'", s)
-			}
-			upperBoundCount++
+	sense, name := strings.ToUpper(fields[0]), fields[1]
+	if _, found := p.rows[name]; found {
+		return mpsErrorf(p.line, "row '%s' duplicated", name)
+	}
+	if _, found := p.freeRows[name]; found {
+		return mpsErrorf(p.line, "row '%s' duplicated", name)
+	}
+
+	switch sense {
+	case "N":
+		p.freeRows[name] = p.line
+		if p.firstN == "" {
+			p.firstN = name
+		}
+	case "E":
+		p.rows[name] = len(p.rowNames)
+		p.rowNames = append(p.rowNames, name)
+	case "L", "G":
+		return mpsErrorf(p.line,
+			"row '%s' has sense %s but only E rows (equalities) are supported in set partitioning", name, sense)
+	default:
+		return mpsErrorf(p.line, "row '%s' has the unknown sense '%s'", name, fields[0])
+	}
+	return nil
+}
 
+func (p *mpsReader) readColumn(s string) error {
+	fields := strings.Fields(s)
+	if len(fields) >= 3 && fields[1] == "'MARKER'" {
+		switch fields[2] {
+		case "'INTORG'":
+			p.inInteger = true
+		case "'INTEND'":
+			p.inInteger = false
 		default:
-			return nil, fmt.Errorf("mps section processing error")
+			return mpsErrorf(p.line, "unknown marker %s", fields[2])
 		}
+		return nil
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if len(fields) != 3 && len(fields) != 5 {
+		fields = splitFixedMPS(s, false)
+	}
+	if len(fields) != 3 && len(fields) != 5 {
+		return mpsErrorf(p.line,
+			"COLUMNS entry should contain a column name and 1 or 2 row and value pairs but found '%s'", s)
 	}
-	if rhsCount != ins.ElementCount {
-		return nil, fmt.Errorf("rhsCount (%d) != ins.m (%d)", rhsCount, ins.ElementCount)
+
+	colIdx, found := p.columns[fields[0]]
+	if !found {
+		colIdx = len(p.columnsList)
+		p.columns[fields[0]] = colIdx
+		p.columnsList = append(p.columnsList,
+			&mpsColumn{name: fields[0], integer: p.inInteger, upper: math.Inf(1), line: p.line})
 	}
-	if upperBoundCount != len(ins.Subsets) {
-		return nil, fmt.Errorf("upperBoundCount (%d) != len(ins.Subsets) (%d)", upperBoundCount, len(ins.Subsets))
+	col := p.columnsList[colIdx]
+	if col.integer != p.inInteger {
+		return mpsErrorf(p.line, "column '%s' is both inside and outside integer markers", col.name)
 	}
 
-	return &ins, nil
+	for i := 1; i < len(fields); i += 2 {
+		value, err := p.parseNumber(fields[i+1])
+		if err != nil {
+			return err
+		}
+		col.coefficients = append(col.coefficients, mpsCoefficient{fields[i], value, p.line})
+	}
+	return nil
 }
 
-func parseMPSSection(s string) (int, error) {
-	// TODO: check sections occur in expected order
+func (p *mpsReader) readRowValues(s string, section string, values map[string]mpsRowValue) error {
+	fields := strings.Fields(s)
+	if len(fields) < 2 || len(fields) > 5 {
+		fields = splitFixedMPS(s, false)
+	}
+	// An odd number of fields means that the first one is the name of the
+	// RHS or RANGES vector which is ignored.
+	if len(fields)%2 == 1 {
+		fields = fields[1:]
+	}
+	if len(fields) != 2 && len(fields) != 4 {
+		return mpsErrorf(p.line, "%s entry should contain 1 or 2 row and value pairs but found '%s'", section, s)
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		value, err := p.parseNumber(fields[i+1])
+		if err != nil {
+			return err
+		}
+		if _, found := values[fields[i]]; found {
+			return mpsErrorf(p.line, "%s for row '%s' duplicated", section, fields[i])
+		}
+		values[fields[i]] = mpsRowValue{value, p.line}
+	}
+	return nil
+}
+
+func (p *mpsReader) readBound(s string) error {
+	b, err := p.parseBound(s, strings.Fields(s))
+	if _, found := p.columns[b.column]; err != nil || !found {
+		// For example, a column name with a space looks like a bound with a
+		// value in free MPS.
+		fixed, fixedErr := p.parseBound(s, splitFixedMPS(s, true))
+		if _, found := p.columns[fixed.column]; fixedErr == nil && found {
+			b, err = fixed, nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	p.bounds = append(p.bounds, b)
+	return nil
+}
+
+func (p *mpsReader) parseBound(s string, fields []string) (mpsBound, error) {
+	if len(fields) < 2 || len(fields) > 4 {
+		return mpsBound{}, mpsErrorf(p.line,
+			"BOUNDS entry should contain a type, a column and maybe a value but found '%s'", s)
+	}
+
+	kind := strings.ToUpper(fields[0])
+	hasValue := true
+	switch kind {
+	case "UP", "LO", "FX", "LI", "UI":
+	case "BV", "MI", "PL", "FR":
+		hasValue = false
+	default:
+		return mpsBound{}, mpsErrorf(p.line, "unsupported bound type '%s'", fields[0])
+	}
+
+	b := mpsBound{kind: kind, line: p.line}
 	switch {
-	case strings.HasPrefix(s, "NAME"):
-		return MPS_SECTION_NAME, nil
-	case strings.HasPrefix(s, "ROWS"):
-		return MPS_SECTION_ROWS, nil
-	case strings.HasPrefix(s, "COLUMNS"):
-		return MPS_SECTION_COLUMNS, nil
-	case strings.HasPrefix(s, "RHS"):
-		return MPS_SECTION_RHS, nil
-	case strings.HasPrefix(s, "BOUNDS"):
-		return MPS_SECTION_BOUNDS, nil
-	case strings.HasPrefix(s, "ENDATA"):
-		return MPS_SECTION_ENDATA, nil
+	case hasValue && len(fields) == 4:
+		b.column = fields[2]
+	case hasValue && len(fields) == 3:
+		b.column = fields[1]
+	case !hasValue && len(fields) >= 3:
+		// Some writers add a value for BV bounds.
+		b.column = fields[2]
+	case !hasValue && len(fields) == 2:
+		b.column = fields[1]
+	default:
+		return mpsBound{}, mpsErrorf(p.line, "BOUNDS entry of type %s is missing a value in '%s'", kind, s)
 	}
-	return MPS_SECTION_NOT_SET, fmt.Errorf("unsupported MPS section '%s'", s)
+	if hasValue {
+		value, err := p.parseNumber(fields[len(fields)-1])
+		if err != nil {
+			return mpsBound{}, err
+		}
+		b.value = value
+	}
+	return b, nil
+}
+
+// namesInFileOrder returns the row names of the entries in the order of their
+// lines so that the first of several problems in the file is reported.
+func namesInFileOrder(entries map[string]mpsRowValue) []string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	// Two entries may be on the same line.
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(entries[a].line, entries[b].line), strings.Compare(a, b))
+	})
+	return names
+}
+
+// makeInstance resolves the names and checks that the model is a set
+// partitioning problem.
+func (p *mpsReader) makeInstance() (*Instance, error) {
+	if p.maximize {
+		return nil, mpsErrorf(0, "the objective sense must be minimization")
+	}
+
+	objective := p.objectiveName
+	if objective == "" {
+		objective = p.firstN
+	} else if _, found := p.freeRows[objective]; !found {
+		return nil, mpsErrorf(0, "the objective row '%s' from OBJNAME is not an N row", objective)
+	}
+	if objective == "" {
+		return nil, mpsErrorf(0, "no objective row (N row) found")
+	}
+
+	for _, name := range namesInFileOrder(p.rhs) {
+		rhs := p.rhs[name]
+		if _, found := p.freeRows[name]; found {
+			if name == objective && rhs.value != 0 {
+				return nil, mpsErrorf(rhs.line, "a constant in the objective (RHS for row '%s') is not supported", name)
+			}
+			continue
+		}
+		if _, found := p.rows[name]; !found {
+			return nil, mpsErrorf(rhs.line, "unknown row '%s' in RHS", name)
+		}
+		if rhs.value != 1 {
+			return nil, mpsErrorf(rhs.line,
+				"row '%s' has the right-hand side %v but set partitioning requires 1", name, rhs.value)
+		}
+	}
+	for i, name := range p.rowNames {
+		if _, found := p.rhs[name]; !found {
+			return nil, mpsErrorf(0,
+				"row '%s' (element %d) has the right-hand side 0 but set partitioning requires 1", name, i)
+		}
+	}
+	for _, name := range namesInFileOrder(p.ranges) {
+		r := p.ranges[name]
+		if _, found := p.rows[name]; !found {
+			return nil, mpsErrorf(r.line, "RANGES entry for row '%s' which is not an E row", name)
+		}
+		if r.value != 0 {
+			return nil, mpsErrorf(r.line,
+				"row '%s' has the range %v but only equality rows are supported in set partitioning", name, r.value)
+		}
+	}
+
+	for _, b := range p.bounds {
+		colIdx, found := p.columns[b.column]
+		if !found {
+			return nil, mpsErrorf(b.line, "unknown column '%s' in BOUNDS", b.column)
+		}
+		col := p.columnsList[colIdx]
+		switch b.kind {
+		case "UP":
+			col.upper = b.value
+		case "UI":
+			col.upper = b.value
+			col.integer = true
+		case "LO":
+			col.lower = b.value
+		case "LI":
+			col.lower = b.value
+			col.integer = true
+		case "BV":
+			col.lower, col.upper = 0, 1
+			col.integer = true
+		case "PL":
+			col.upper = math.Inf(1)
+		case "FX":
+			col.lower, col.upper = b.value, b.value
+		case "MI":
+			col.lower = math.Inf(-1)
+		case "FR":
+			col.lower, col.upper = math.Inf(-1), math.Inf(1)
+		}
+	}
+
+	ins := Instance{
+		ElementCount: len(p.rowNames),
+		Subsets:      make([][]int, 0, len(p.columnsList)),
+		Costs:        make([]float64, 0, len(p.columnsList)),
+		ElementNames: p.rowNames,
+		SubsetNames:  make([]string, 0, len(p.columnsList)),
+	}
+
+	for _, col := range p.columnsList {
+		// Many writers do not mark binary columns as integer but only give
+		// them the upper bound 1.
+		if !col.integer && !(col.lower == 0 && col.upper == 1) {
+			return nil, mpsErrorf(col.line,
+				"column '%s' is continuous but set partitioning requires binary columns", col.name)
+		}
+		if col.lower != 0 {
+			return nil, mpsErrorf(col.line,
+				"column '%s' has the lower bound %v but set partitioning requires 0", col.name, col.lower)
+		}
+		if col.upper < 1 {
+			return nil, mpsErrorf(col.line,
+				"column '%s' has the upper bound %v but set partitioning requires at least 1", col.name, col.upper)
+		}
+
+		cost := 0.0
+		hasCost := false
+		subset := make([]int, 0, len(col.coefficients))
+		for _, c := range col.coefficients {
+			if c.row == objective {
+				if hasCost {
+					return nil, mpsErrorf(c.line,
+						"column '%s' has more than one coefficient for row '%s'", col.name, objective)
+				}
+				cost, hasCost = c.value, true
+				continue
+			}
+			if _, found := p.freeRows[c.row]; found {
+				continue
+			}
+			rowIdx, found := p.rows[c.row]
+			if !found {
+				return nil, mpsErrorf(c.line, "unknown row '%s' in column '%s'", c.row, col.name)
+			}
+			if c.value == 0 {
+				continue
+			}
+			if c.value != 1 {
+				return nil, mpsErrorf(c.line,
+					"column '%s' has the coefficient %v in row '%s' but set partitioning requires 1",
+					col.name, c.value, c.row)
+			}
+			subset = append(subset, rowIdx)
+		}
+
+		if len(subset) == 0 {
+			return nil, mpsErrorf(col.line,
+				"column '%s' is in no E row but set partitioning requires nonempty subsets", col.name)
+		}
+		slices.Sort(subset)
+		for k := 1; k < len(subset); k++ {
+			if subset[k-1] == subset[k] {
+				return nil, mpsErrorf(col.line,
+					"column '%s' has more than one coefficient for row '%s'", col.name, p.rowNames[subset[k]])
+			}
+		}
+
+		ins.Subsets = append(ins.Subsets, subset)
+		ins.Costs = append(ins.Costs, cost)
+		ins.SubsetNames = append(ins.SubsetNames, col.name)
+	}
+
+	return &ins, nil
 }
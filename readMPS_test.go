@@ -0,0 +1,232 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// The instance in the free and fixed MPS tests below.
+var mpsTestInstance = Instance{
+	ElementCount: 3,
+	Subsets:      [][]int{{0, 1}, {2}, {0, 1, 2}},
+	Costs:        []float64{1.5, 2, 4},
+	ElementNames: []string{"R1", "R2", "R3"},
+	SubsetNames:  []string{"X1", "X2", "X3"},
+}
+
+func TestReadFreeMPS(t *testing.T) {
+	mps := `NAME test
+* A comment
+ROWS
+ N obj
+ E R1
+ E R2
+ E R3
+COLUMNS
+    MARKER 'MARKER' 'INTORG'
+    X1 obj 1.5 R1 1
+    X1 R2 1
+    X2 obj 2 R3 1
+    X3 R1 1 R2 1
+    X3 R3 1. obj 4
+    MARKER 'MARKER' 'INTEND'
+RHS
+    RHS R1 1 R2 1
+    RHS R3 1
+BOUNDS
+ UP BND X1 1
+ UP BND X2 1
+ UP BND X3 1
+ENDATA
+`
+	ins, err := ReadMPS(strings.NewReader(mps))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, mpsTestInstance)
+}
+
+func TestReadMPSReportsTheFirstProblem(t *testing.T) {
+	mps := `NAME
+ROWS
+ N COST
+ E R1
+ E R2
+ E R3
+COLUMNS
+    X COST 1 R1 1
+    X R2 1 R3 1
+RHS
+    RHS R3 3
+    RHS R1 2 R2 2
+ENDATA
+`
+	// The entries are kept in maps, so check that the order is not random.
+	for k := 0; k < 10; k++ {
+		_, err := ReadMPS(strings.NewReader(mps))
+		assert.ErrorContains(t, err, "MPS line 11: row 'R3' has the right-hand side 3")
+	}
+}
+
+func TestReadMPSUpperBoundIsBinary(t *testing.T) {
+	// Without integer markers, the upper bound 1 makes the columns binary.
+	mps := `NAME test
+ROWS
+ N obj
+ E R1
+ E R2
+ E R3
+COLUMNS
+    X1 obj 1.5 R1 1
+    X1 R2 1
+    X2 obj 2 R3 1
+    X3 R1 1 R2 1
+    X3 R3 1. obj 4
+RHS
+    RHS R1 1 R2 1
+    RHS R3 1
+BOUNDS
+ UP BND X1 1
+ UP BND X2 1
+ UP BND X3 1
+ENDATA
+`
+	ins, err := ReadMPS(strings.NewReader(mps))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, mpsTestInstance)
+
+	_, err = ReadMPS(strings.NewReader(strings.Replace(mps, " UP BND X3 1", " UP BND X3 2", 1)))
+	assert.ErrorContains(t, err, "column 'X3' is continuous")
+}
+
+func TestReadFixedMPS(t *testing.T) {
+	// The column names contain spaces so the fixed format is needed.
+	mps := `NAME          TEST
+ROWS
+ N  COST
+ E  R1
+ E  R2
+ E  R3
+COLUMNS
+    X 1       COST               1.5   R1                  1.
+    X 1       R2                  1.
+    X 2       COST                 2   R3                  1.
+    X 3       R1                  1.   R2                  1.
+    X 3       R3                  1.   COST                 4
+RHS
+    RHS       R1                  1.   R2                  1.
+    RHS       R3                  1.
+BOUNDS
+ BV BND       X 1
+ BV BND       X 2
+ BV BND       X 3
+ENDATA
+`
+	ins, err := ReadMPS(strings.NewReader(mps))
+	assert.NilError(t, err)
+	expected := mpsTestInstance
+	expected.SubsetNames = []string{"X 1", "X 2", "X 3"}
+	assert.DeepEqual(t, *ins, expected)
+}
+
+func TestReadMPSSectionsInAnyOrder(t *testing.T) {
+	mps := `NAME
+OBJSENSE
+    MIN
+OBJNAME
+    cost
+RHS
+    R1 1
+    R2 1
+    R3 1
+COLUMNS
+    X1 cost 1.5 R1 1
+    X1 R2 1 other 7
+    X2 cost 2 R3 1
+    X3 R1 1 R2 1
+    X3 R3 1 cost 4
+RANGES
+    RNG R1 0
+BOUNDS
+ BV X1
+ BV X2
+ BV BND X3
+ROWS
+ N other
+ N cost
+ E R1
+ E R2
+ E R3
+ENDATA
+`
+	ins, err := ReadMPS(strings.NewReader(mps))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, mpsTestInstance)
+}
+
+func TestReadMPSErrors(t *testing.T) {
+	valid := `NAME
+ROWS
+ N COST
+ E R1
+COLUMNS
+    MARKER 'MARKER' 'INTORG'
+    X COST 1 R1 1
+    MARKER 'MARKER' 'INTEND'
+RHS
+    RHS R1 1
+ENDATA
+`
+	_, err := ReadMPS(strings.NewReader(valid))
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name     string
+		old, new string
+		line     int
+		msg      string
+	}{
+		{"L row", " E R1", " L R1", 4, "row 'R1' has sense L but only E rows"},
+		{"coefficient", "X COST 1 R1 1", "X COST 1 R1 2", 7, "coefficient 2 in row 'R1'"},
+		{"rhs", "RHS R1 1", "RHS R1 2", 10, "right-hand side 2"},
+		{"missing rhs", "    RHS R1 1\n", "", 0, "right-hand side 0"},
+		{"continuous", "    MARKER 'MARKER' 'INTORG'\n", "", 6, "column 'X' is continuous"},
+		{"unknown row", "X COST 1 R1 1", "X COST 1 R2 1", 7, "unknown row 'R2'"},
+		{"number", "X COST 1 R1 1", "X COST one R1 1", 7, "unable to parse number 'one'"},
+		{"maximize", "NAME\n", "NAME\nOBJSENSE MAX\n", 0, "minimization"},
+		{"bound", "RHS\n", "BOUNDS\n LO BND X 1\nRHS\n", 7, "lower bound 1"},
+		{"SOS", "ENDATA", "SOS\nENDATA", 11, "section SOS is not supported"},
+		{"ENDATA", "ENDATA\n", "", 0, "missing ENDATA"},
+		{"objective twice", "X COST 1 R1 1", "X COST 1 R1 1\n    X COST 2", 8, "more than one coefficient for row 'COST'"},
+		{"empty column", "X COST 1 R1 1", "X COST 1", 7, "column 'X' is in no E row"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mps := strings.Replace(valid, tc.old, tc.new, 1)
+			assert.Assert(t, mps != valid)
+			_, err := ReadMPS(strings.NewReader(mps))
+			var mpsErr *MPSError
+			assert.Assert(t, errors.As(err, &mpsErr), "%v", err)
+			assert.Equal(t, mpsErr.Line, tc.line)
+			assert.ErrorContains(t, err, tc.msg)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestReadJSON(t *testing.T) {
+	ins := MakeRandomInstance(8, 20, 10, 3)
+	ins.ElementNames = []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	b, err := json.Marshal(ins)
+	assert.NilError(t, err)
+
+	read, err := ReadJSON(bytes.NewReader(b))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *read, ins)
+
+	read, err = ReadJSON(strings.NewReader(
+		`{"elementCount": 2, "Comment": {"x": [1]}, "subsets": [[0], [0, 1]], "costs": [1, 2]}`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *read, Instance{ElementCount: 2, Subsets: [][]int{{0}, {0, 1}}, Costs: []float64{1, 2}})
+
+	_, err = ReadJSON(strings.NewReader(`{"ElementCount": 2, "Subsets": [[0], ["a"]]}`))
+	assert.ErrorContains(t, err, "unable to read Subsets")
+	_, err = ReadJSON(strings.NewReader(`[]`))
+	assert.ErrorContains(t, err, "expected '{'")
+	_, err = ReadJSON(strings.NewReader(`{"ElementCount": 2`))
+	assert.ErrorContains(t, err, "JSON instance")
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := map[string]Format{
+		"a.json":          FormatJSON,
+		"dir/A.JSON.gz":   FormatJSON,
+		"air04.mps.gz":    FormatMPS,
+		"b.MPS.bz2":       FormatMPS,
+		"x.scp":           FormatORLibSCP,
+		"x.spp":           FormatORLibSPP,
+		"scp41.txt":       FormatORLibSCP,
+		"SPPNW01.TXT.gz":  FormatORLibSPP,
+		"notes.txt":       FormatUnknown,
+		"instance":        FormatUnknown,
+		"instance.gz":     FormatUnknown,
+		"scp41.json.mps":  FormatMPS,
+		"sppnw01.txt.zip": FormatUnknown,
+	}
+	for filename, want := range tests {
+		assert.Equal(t, FormatFromFilename(filename), want, filename)
+	}
+}
+
+func TestReadInstance(t *testing.T) {
+	dir := t.TempDir()
+	var mps bytes.Buffer
+	assert.NilError(t, WriteMPS(&mps, mpsTestInstance))
+
+	write := func(name string, content []byte, compress bool) string {
+		if compress {
+			var b bytes.Buffer
+			w := gzip.NewWriter(&b)
+			_, err := w.Write(content)
+			assert.NilError(t, err)
+			assert.NilError(t, w.Close())
+			content = b.Bytes()
+		}
+		path := filepath.Join(dir, name)
+		assert.NilError(t, os.WriteFile(path, content, 0o600))
+		return path
+	}
+
+	for _, path := range []string{
+		write("a.mps", mps.Bytes(), false),
+		write("a.mps.gz", mps.Bytes(), true),
+		// The compression and format are found from the content.
+		write("a", mps.Bytes(), true),
+	} {
+		ins, err := ReadInstance(path)
+		assert.NilError(t, err, path)
+		assert.DeepEqual(t, *ins, mpsTestInstance)
+	}
+
+	ins, err := ReadMPSInstance(write("b.gz", mps.Bytes(), true))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, mpsTestInstance)
+
+	b, err := json.Marshal(mpsTestInstance)
+	assert.NilError(t, err)
+	ins, err = ReadInstance(write("c", b, false))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, mpsTestInstance)
+
+	_, err = ReadInstance(write("d", []byte("3 3\n"), false))
+	assert.ErrorContains(t, err, "unable to determine the format")
+}
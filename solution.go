@@ -0,0 +1,250 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SolutionStatus is the status of a Solution.
+type SolutionStatus string
+
+const (
+	// The solution is proven to be optimal.
+	StatusOptimal SolutionStatus = "optimal"
+	// The solution is an exact cover but it is not proven to be optimal.
+	StatusFeasible SolutionStatus = "feasible"
+	// The instance is proven to have no exact cover.
+	StatusInfeasible SolutionStatus = "infeasible"
+	// No exact cover was found but the instance is not proven infeasible,
+	// e.g. a limit was reached.
+	StatusUnknown SolutionStatus = "unknown"
+)
+
+// SolveStatistics are statistics about how a solution was found.
+type SolveStatistics struct {
+	Solver      string  `json:"solver,omitempty"` // e.g. "branch-and-bound"
+	Nodes       int     `json:"nodes,omitempty"`  // branch and bound nodes processed
+	TimeSeconds float64 `json:"timeSeconds,omitempty"`
+}
+
+// Solution is the solution file format. It is a JSON object, e.g.
+//
+//	{
+//	  "status": "optimal",
+//	  "cost": 3180.335134842885,
+//	  "bound": 3180.335134842885,
+//	  "gap": 0,
+//	  "solution": [22, 30],
+//	  "subsetNames": ["x22", "x30"],
+//	  "statistics": {"solver": "branch-and-bound", "nodes": 7}
+//	}
+//
+// The keys status, cost and solution are the same as in the results of
+// tools/solve_sc.py so those results can be read as Solutions. Only status
+// is required. Bound and gap are omitted when they are unknown (NaN) or
+// infinite since JSON has no representation of them.
+type Solution struct {
+	Status SolutionStatus
+	// The sum of the costs of the subsets in Solution.
+	Cost float64
+	// A lower bound on the optimal cost. NaN if unknown and +Inf if the
+	// instance is infeasible.
+	Bound float64
+	// The relative gap (Cost - Bound) / Cost. NaN if unknown.
+	Gap float64
+	// The indices of the subsets in the solution.
+	Solution []int
+	// The names of the subsets in Solution if the instance has subset names.
+	SubsetNames []string
+	Statistics  *SolveStatistics
+}
+
+// NewSolution makes a Solution from a solver's result for the instance. An
+// eval that does not exactly cover the instance is taken as a proof of
+// infeasibility since that is what the solvers return for infeasible
+// instances.
+func NewSolution(ins Instance, eval SubsetsEval) Solution {
+	sol := Solution{
+		Status:      StatusInfeasible,
+		Cost:        eval.Cost,
+		Bound:       math.Inf(1),
+		Gap:         math.NaN(),
+		Solution:    eval.SubsetsIndices,
+		SubsetNames: ins.SubsetNamesOf(eval.SubsetsIndices),
+	}
+	if sol.Solution == nil {
+		sol.Solution = make([]int, 0)
+	}
+
+	switch {
+	case eval.Optimal:
+		sol.Status = StatusOptimal
+		sol.Bound = eval.Cost
+		sol.Gap = 0
+	case eval.ExactlyCovered:
+		sol.Status = StatusFeasible
+		sol.Bound = math.NaN()
+	}
+	return sol
+}
+
+// solutionJSON is the JSON representation of a Solution where the fields
+// that may not be finite are optional.
+type solutionJSON struct {
+	Status      SolutionStatus   `json:"status"`
+	Cost        float64          `json:"cost"`
+	Bound       *float64         `json:"bound,omitempty"`
+	Gap         *float64         `json:"gap,omitempty"`
+	Solution    []int            `json:"solution"`
+	SubsetNames []string         `json:"subsetNames,omitempty"`
+	Statistics  *SolveStatistics `json:"statistics,omitempty"`
+}
+
+func finiteOrNil(x float64) *float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil
+	}
+	return &x
+}
+
+func (s Solution) MarshalJSON() ([]byte, error) {
+	return json.Marshal(solutionJSON{
+		Status:      s.Status,
+		Cost:        s.Cost,
+		Bound:       finiteOrNil(s.Bound),
+		Gap:         finiteOrNil(s.Gap),
+		Solution:    s.Solution,
+		SubsetNames: s.SubsetNames,
+		Statistics:  s.Statistics,
+	})
+}
+
+func (s *Solution) UnmarshalJSON(b []byte) error {
+	var aux solutionJSON
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	*s = Solution{
+		Status:      aux.Status,
+		Cost:        aux.Cost,
+		Bound:       math.NaN(),
+		Gap:         math.NaN(),
+		Solution:    aux.Solution,
+		SubsetNames: aux.SubsetNames,
+		Statistics:  aux.Statistics,
+	}
+	if aux.Bound != nil {
+		s.Bound = *aux.Bound
+	} else if s.Status == StatusInfeasible {
+		s.Bound = math.Inf(1)
+	}
+	if aux.Gap != nil {
+		s.Gap = *aux.Gap
+	}
+	return nil
+}
+
+// WriteSolution writes the solution as indented JSON.
+func WriteSolution(w io.Writer, sol Solution) error {
+	b, err := json.MarshalIndent(sol, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// ReadSolution reads a solution written by WriteSolution or tools/solve_sc.py.
+func ReadSolution(r io.Reader) (*Solution, error) {
+	var sol Solution
+	if err := json.NewDecoder(r).Decode(&sol); err != nil {
+		return nil, err
+	}
+	switch sol.Status {
+	case StatusOptimal, StatusFeasible, StatusInfeasible, StatusUnknown:
+	default:
+		return nil, fmt.Errorf("unknown solution status %q", sol.Status)
+	}
+	return &sol, nil
+}
+
+// CheckSolution checks that the solution is valid for the instance using
+// Verify. The indices must be valid and unique, the subsets' elements must
+// be in the instance, the subset names, if any, must match the instance's
+// and the cost must match the sum of the subsets' costs (see
+// Verification.CostMatches). An optimal or feasible solution must
+// be an exact cover and an infeasible or unknown solution must have no
+// subsets. The bound must not be greater than the cost. All problems found
+// are returned joined together.
+func CheckSolution(ins Instance, sol Solution) error {
+	var errs []error
+	v := Verify(ins, sol.Solution)
+	for _, j := range v.InvalidIndices {
+		errs = append(errs, fmt.Errorf("subset index %d is not in [0, %d)", j, len(ins.Subsets)))
+	}
+	for _, j := range v.DuplicateIndices {
+		errs = append(errs, fmt.Errorf("subset %d is in the solution more than once", j))
+	}
+	for _, i := range v.InvalidElements {
+		errs = append(errs, fmt.Errorf("element %d of a subset in the solution is not in [0, %d)", i, ins.ElementCount))
+	}
+
+	if sol.SubsetNames != nil {
+		if len(sol.SubsetNames) != len(sol.Solution) {
+			errs = append(errs, &LengthMismatchError{"SubsetNames", len(sol.SubsetNames), len(sol.Solution)})
+		} else {
+			for k, j := range sol.Solution {
+				if j >= 0 && j < len(ins.Subsets) && sol.SubsetNames[k] != ins.SubsetName(j) {
+					errs = append(errs, fmt.Errorf(
+						"subset %d is named %q in the solution but %q in the instance",
+						j, sol.SubsetNames[k], ins.SubsetName(j)))
+				}
+			}
+		}
+	}
+
+	if !v.CostMatches(sol.Cost) {
+		errs = append(errs, fmt.Errorf("the solution cost is %v but the subsets' costs sum to %v", sol.Cost, v.Cost))
+	}
+	if sol.Bound > sol.Cost && sol.Status != StatusInfeasible && sol.Status != StatusUnknown {
+		errs = append(errs, fmt.Errorf("the bound %v is greater than the cost %v", sol.Bound, sol.Cost))
+	}
+
+	switch sol.Status {
+	case StatusOptimal, StatusFeasible:
+		for _, i := range v.OverCovered {
+			errs = append(errs, fmt.Errorf("element %d is covered %d times", i, v.Coverage[i]))
+		}
+		for _, i := range v.UnderCovered {
+			errs = append(errs, fmt.Errorf("element %d is not covered", i))
+		}
+	case StatusInfeasible, StatusUnknown:
+		if len(sol.Solution) > 0 {
+			errs = append(errs, fmt.Errorf("a solution with status %s has subsets", sol.Status))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown solution status %q", sol.Status))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,107 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSolutionRoundTrip(t *testing.T) {
+	ins := mpsTestInstance
+	sol := NewSolution(ins, SubsetsEval{SubsetsIndices: []int{0, 1}, ExactlyCovered: true, Cost: 3.5})
+	sol.Statistics = &SolveStatistics{Solver: "test", Nodes: 3}
+	assert.Equal(t, sol.Status, StatusFeasible)
+	assert.DeepEqual(t, sol.SubsetNames, []string{"X1", "X2"})
+	assert.NilError(t, CheckSolution(ins, sol))
+
+	var b bytes.Buffer
+	assert.NilError(t, WriteSolution(&b, sol))
+	// The unknown bound and gap are omitted.
+	assert.Assert(t, !strings.Contains(b.String(), "bound"), b.String())
+	read, err := ReadSolution(&b)
+	assert.NilError(t, err)
+	assert.Assert(t, math.IsNaN(read.Bound) && math.IsNaN(read.Gap))
+	// NaN != NaN so compare the rest.
+	read.Bound, read.Gap = 0, 0
+	sol.Bound, sol.Gap = 0, 0
+	assert.DeepEqual(t, *read, sol)
+
+	infeasible := NewSolution(ins, SubsetsEval{})
+	b.Reset()
+	assert.NilError(t, WriteSolution(&b, infeasible))
+	read, err = ReadSolution(&b)
+	assert.NilError(t, err)
+	assert.Equal(t, read.Status, StatusInfeasible)
+	assert.Assert(t, math.IsInf(read.Bound, 1))
+	assert.DeepEqual(t, read.Solution, []int{})
+	assert.NilError(t, CheckSolution(ins, *read))
+
+	_, err = ReadSolution(strings.NewReader(`{"status": "solved"}`))
+	assert.ErrorContains(t, err, `unknown solution status "solved"`)
+}
+
+// The results of tools/solve_sc.py are valid solutions.
+func TestReadPythonSolutions(t *testing.T) {
+	var specifications []TestInstanceSpecification
+	b, err := os.ReadFile("testdata/tiny_instance_specifications.json")
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(b, &specifications))
+
+	for _, spec := range specifications {
+		ins, err := ReadJsonInstance(spec.InstancePath)
+		assert.NilError(t, err)
+		file, err := os.Open(spec.PythonSolutionPath)
+		assert.NilError(t, err)
+		sol, err := ReadSolution(file)
+		file.Close()
+		assert.NilError(t, err)
+		assert.NilError(t, CheckSolution(*ins, *sol), spec.PythonSolutionPath)
+	}
+}
+
+func TestCheckSolution(t *testing.T) {
+	ins := mpsTestInstance
+	sol := Solution{
+		Status:      StatusOptimal,
+		Cost:        6,
+		Bound:       7,
+		Solution:    []int{0, 2, 2, 3},
+		SubsetNames: []string{"X1", "X2", "X3", "X4"},
+	}
+	err := CheckSolution(ins, sol)
+	assert.ErrorContains(t, err, "subset index 3 is not in [0, 3)")
+	assert.ErrorContains(t, err, "subset 2 is in the solution more than once")
+	assert.ErrorContains(t, err, `subset 2 is named "X2" in the solution but "X3" in the instance`)
+	assert.ErrorContains(t, err, "the solution cost is 6 but the subsets' costs sum to 9.5")
+	assert.ErrorContains(t, err, "the bound 7 is greater than the cost 6")
+	assert.ErrorContains(t, err, "element 0 is covered 3 times")
+
+	err = CheckSolution(ins, Solution{Status: StatusUnknown, Cost: 2, Solution: []int{1}})
+	assert.ErrorContains(t, err, "a solution with status unknown has subsets")
+
+	invalid := Instance{ElementCount: 2, Subsets: [][]int{{0}, {5}}, Costs: []float64{1, 1}}
+	err = CheckSolution(invalid, Solution{Status: StatusOptimal, Cost: 1, Solution: []int{1}})
+	assert.ErrorContains(t, err, "element 5 of a subset in the solution is not in [0, 2)")
+}
@@ -18,6 +18,8 @@
 package solvers
 
 import (
+	"io"
+
 	"github.com/snow-abstraction/cover"
 	"github.com/snow-abstraction/cover/internal/solvers"
 )
@@ -32,6 +34,74 @@ func SolveByBranchAndBound(ins cover.Instance) (cover.SubsetsEval, error) {
 	return solvers.SolveByBranchAndBound(ins)
 }
 
+// Options are limits for SolveByBranchAndBoundWithOptions. The zero value
+// has no limits.
+type Options = solvers.Options
+
+// Stats are statistics returned by SolveByBranchAndBoundWithOptions.
+type Stats = solvers.Stats
+
+// Progress is the state of a branch-and-bound run passed to
+// Options.OnProgress.
+type Progress = solvers.Progress
+
+// LogProgress returns an Options.OnProgress callback that logs a table line
+// at Info level per call, in the style of MIP solvers.
+func LogProgress() func(Progress) {
+	return solvers.LogProgress()
+}
+
+// Recorder records the nodes processed by the branch-and-bound solver when
+// set in the Options.
+type Recorder = solvers.Recorder
+
+// NodeRecord is what a Recorder keeps about one branch-and-bound node.
+type NodeRecord = solvers.NodeRecord
+
+// NewRecorder creates an empty recorder.
+func NewRecorder() *Recorder {
+	return solvers.NewRecorder()
+}
+
+// Checkpoint is the state of a branch-and-bound search passed to
+// Options.OnCheckpoint that can be resumed from using Options.Resume.
+type Checkpoint = solvers.Checkpoint
+
+// CheckpointSolution, CheckpointNode and CheckpointBranch are parts of a
+// Checkpoint.
+type (
+	CheckpointSolution = solvers.CheckpointSolution
+	CheckpointNode     = solvers.CheckpointNode
+	CheckpointBranch   = solvers.CheckpointBranch
+)
+
+// ErrCheckpointMismatch is returned when resuming from a checkpoint of
+// another instance.
+var ErrCheckpointMismatch = solvers.ErrCheckpointMismatch
+
+// InstanceHash is the hash identifying the instance of a checkpoint.
+func InstanceHash(ins cover.Instance) (string, error) {
+	return solvers.InstanceHash(ins)
+}
+
+// WriteCheckpoint writes the checkpoint as JSON.
+func WriteCheckpoint(w io.Writer, c Checkpoint) error {
+	return solvers.WriteCheckpoint(w, c)
+}
+
+// ReadCheckpoint reads a checkpoint written by WriteCheckpoint.
+func ReadCheckpoint(r io.Reader) (*Checkpoint, error) {
+	return solvers.ReadCheckpoint(r)
+}
+
+// SolveByBranchAndBoundWithOptions is like SolveByBranchAndBound but stops
+// when a limit of the options is reached. Then the best exact cover found so
+// far is returned with its optimal flag false, or the zero value of
+// subsetEval if none was found, and Stats.LimitReached is true.
+func SolveByBranchAndBoundWithOptions(ins cover.Instance, opts Options) (cover.SubsetsEval, Stats, error) {
+	return solvers.SolveByBranchAndBoundWithOptions(ins, opts)
+}
+
 // SolveByBruteForce attempts finds a minimum cost exact cover for
 // an instance by evaluating all possible selections of the subsets.
 //
@@ -41,3 +111,49 @@ func SolveByBranchAndBound(ins cover.Instance) (cover.SubsetsEval, error) {
 func SolveByBruteForce(ins cover.Instance) (cover.SubsetsEval, error) {
 	return solvers.SolveByBruteForce(ins)
 }
+
+// SolveByDancingLinks attempts finds a minimum cost exact cover for an
+// instance by using Knuth's Algorithm X with Dancing Links and the MRV
+// heuristic, pruning partial covers that can not be completed to a cheaper
+// cover than the best found.
+//
+// If a minimum cost exact cover exists, the returned subsetsEval will contain
+// indices to this cover and its exactlyCovered flag will be true. Otherwise,
+// the zero value of subsetEval will be returned.
+func SolveByDancingLinks(ins cover.Instance) (cover.SubsetsEval, error) {
+	return solvers.SolveByDancingLinks(ins)
+}
+
+// DancingLinksOptions are options for SolveByDancingLinksWithOptions.
+type DancingLinksOptions = solvers.DancingLinksOptions
+
+// SolveByDancingLinksWithOptions is SolveByDancingLinks with options, e.g.
+// to also prune using the Lagrangian bound.
+func SolveByDancingLinksWithOptions(ins cover.Instance, opts DancingLinksOptions) (cover.SubsetsEval, error) {
+	return solvers.SolveByDancingLinksWithOptions(ins, opts)
+}
+
+// FindExactCovers finds up to k exact covers of the instance, or all if
+// k <= 0, ignoring the costs. Each cover is a list of subset indices.
+func FindExactCovers(ins cover.Instance, k int) ([][]int, error) {
+	return solvers.FindExactCovers(ins, k)
+}
+
+// Solver is the signature of the solvers in this package, e.g.
+// SolveByBranchAndBound.
+type Solver = solvers.Solver
+
+// SolveByComponents splits the instance into independent instances, one per
+// connected component of the element-subset incidence graph (see
+// cover.Components), and solves them using solve. With workers > 1 the
+// components are solved concurrently.
+//
+// If every component has a minimum cost exact cover, the returned
+// subsetsEval will contain the indices of the combined cover and its
+// exactlyCovered flag will be true. Otherwise, the zero value of subsetEval
+// will be returned. Since solve is called once per component, limits of
+// solve, e.g. Options.NodeLimit and Options.TimeLimit, apply to each
+// component separately.
+func SolveByComponents(ins cover.Instance, solve Solver, workers int) (cover.SubsetsEval, error) {
+	return solvers.SolveByComponents(ins, solve, workers)
+}
@@ -0,0 +1,142 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ElementCountError reports that the number of elements is negative.
+type ElementCountError struct {
+	ElementCount int
+}
+
+func (e *ElementCountError) Error() string {
+	return fmt.Sprintf("the number of elements must be nonnegative. %d was supplied", e.ElementCount)
+}
+
+// EmptySubsetError reports that a subset has no elements.
+type EmptySubsetError struct {
+	Subset int // index of the subset
+}
+
+func (e *EmptySubsetError) Error() string {
+	return fmt.Sprintf("subset %d is empty. Empty subsets are not allowed", e.Subset)
+}
+
+// ElementOutOfRangeError reports that a subset contains an element index
+// outside of [0, ElementCount).
+type ElementOutOfRangeError struct {
+	Subset       int // index of the subset
+	Element      int // the element index out of range
+	ElementCount int
+}
+
+func (e *ElementOutOfRangeError) Error() string {
+	return fmt.Sprintf("subset %d contains element %d which is not a member of [0, %d)",
+		e.Subset, e.Element, e.ElementCount)
+}
+
+// UnsortedSubsetError reports that the elements of a subset are not sorted
+// or that the subset contains duplicate elements.
+type UnsortedSubsetError struct {
+	Subset int // index of the subset
+	// Position in the subset of the first element that is not greater than
+	// the element before it.
+	Position int
+}
+
+func (e *UnsortedSubsetError) Error() string {
+	return fmt.Sprintf(
+		"subset %d is not sorted or contains duplicate elements (at position %d)", e.Subset, e.Position)
+}
+
+// NonPositiveCostError reports that a subset's cost is not strictly positive.
+type NonPositiveCostError struct {
+	Subset int // index of the subset
+	Cost   float64
+}
+
+func (e *NonPositiveCostError) Error() string {
+	return fmt.Sprintf(
+		"subset %d has the cost %v but only strictly positive costs are supported", e.Subset, e.Cost)
+}
+
+// LengthMismatchError reports that the length of one of the Instance's slices
+// does not match what is implied by other fields.
+type LengthMismatchError struct {
+	Field  string // name of the Instance field, e.g. "Costs"
+	Length int    // actual length
+	Want   int    // expected length
+}
+
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf("the length of %s is %d but should be %d", e.Field, e.Length, e.Want)
+}
+
+// Validate checks the constraints that an Instance should satisfy, see
+// the Instance documentation. All problems found are returned joined
+// together (see errors.Join) so individual problems can be found using
+// errors.As with the error types in this file. nil is returned if the
+// instance is valid.
+func Validate(ins Instance) error {
+	if ins.ElementCount < 0 {
+		return &ElementCountError{ins.ElementCount}
+	}
+
+	var errs []error
+	if len(ins.Costs) != len(ins.Subsets) {
+		errs = append(errs, &LengthMismatchError{"Costs", len(ins.Costs), len(ins.Subsets)})
+	}
+	if ins.ElementNames != nil && len(ins.ElementNames) != ins.ElementCount {
+		errs = append(errs, &LengthMismatchError{"ElementNames", len(ins.ElementNames), ins.ElementCount})
+	}
+	if ins.SubsetNames != nil && len(ins.SubsetNames) != len(ins.Subsets) {
+		errs = append(errs, &LengthMismatchError{"SubsetNames", len(ins.SubsetNames), len(ins.Subsets)})
+	}
+
+	for j, subset := range ins.Subsets {
+		if len(subset) == 0 {
+			errs = append(errs, &EmptySubsetError{j})
+			continue
+		}
+
+		for _, element := range subset {
+			if element < 0 || element >= ins.ElementCount {
+				errs = append(errs, &ElementOutOfRangeError{j, element, ins.ElementCount})
+				break
+			}
+		}
+
+		for pos := 1; pos < len(subset); pos++ {
+			if subset[pos-1] >= subset[pos] {
+				errs = append(errs, &UnsortedSubsetError{j, pos})
+				break
+			}
+		}
+	}
+
+	for j, cost := range ins.Costs {
+		if !(cost > 0) {
+			errs = append(errs, &NonPositiveCostError{j, cost})
+		}
+	}
+
+	return errors.Join(errs...)
+}
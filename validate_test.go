@@ -0,0 +1,81 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateValidInstance(t *testing.T) {
+	ins := Instance{ElementCount: 2, Subsets: [][]int{{0, 1}, {1}}, Costs: []float64{1, 2}}
+	assert.NilError(t, Validate(ins))
+	assert.NilError(t, Validate(Instance{}))
+}
+
+func TestValidateReportsTypedErrors(t *testing.T) {
+	ins := Instance{
+		ElementCount: 3,
+		Subsets:      [][]int{{}, {0, 3}, {2, 1}, {1}},
+		Costs:        []float64{1, 1, 1, -2, 1},
+		SubsetNames:  []string{"a"},
+	}
+	err := Validate(ins)
+	assert.Assert(t, err != nil)
+
+	var empty *EmptySubsetError
+	assert.Assert(t, errors.As(err, &empty))
+	assert.Equal(t, *empty, EmptySubsetError{Subset: 0})
+
+	var outOfRange *ElementOutOfRangeError
+	assert.Assert(t, errors.As(err, &outOfRange))
+	assert.Equal(t, *outOfRange, ElementOutOfRangeError{Subset: 1, Element: 3, ElementCount: 3})
+
+	var unsorted *UnsortedSubsetError
+	assert.Assert(t, errors.As(err, &unsorted))
+	assert.Equal(t, *unsorted, UnsortedSubsetError{Subset: 2, Position: 1})
+
+	var cost *NonPositiveCostError
+	assert.Assert(t, errors.As(err, &cost))
+	assert.Equal(t, *cost, NonPositiveCostError{Subset: 3, Cost: -2})
+
+	var mismatch *LengthMismatchError
+	assert.Assert(t, errors.As(err, &mismatch))
+	assert.Equal(t, *mismatch, LengthMismatchError{Field: "Costs", Length: 5, Want: 4})
+}
+
+func TestValidateNegativeElementCount(t *testing.T) {
+	err := Validate(Instance{ElementCount: -1})
+	var countErr *ElementCountError
+	assert.Assert(t, errors.As(err, &countErr))
+	assert.Equal(t, countErr.ElementCount, -1)
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	ins := Instance{
+		ElementCount: 2,
+		Subsets:      [][]int{{0, 0}, {1, 1}},
+		Costs:        []float64{1, 1},
+	}
+	err := Validate(ins)
+	joined, ok := err.(interface{ Unwrap() []error })
+	assert.Assert(t, ok)
+	assert.Equal(t, len(joined.Unwrap()), 2)
+}
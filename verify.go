@@ -0,0 +1,97 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import "math"
+
+// costTolerance is the relative tolerance used when comparing a claimed cost
+// with the recomputed cost.
+const costTolerance = 1e-9
+
+// Verification is the result of Verify.
+type Verification struct {
+	// Coverage[i] is the number of subsets covering element i.
+	Coverage []int
+	// The elements covered more than once, in increasing order.
+	OverCovered []int
+	// The elements not covered, in increasing order.
+	UnderCovered []int
+	// The indices that are not subset indices of the instance, in the order
+	// given. They are otherwise ignored.
+	InvalidIndices []int
+	// The subset indices given more than once, in the order of their
+	// repetitions. Each repetition is counted in Coverage and Cost.
+	DuplicateIndices []int
+	// The elements of the subsets that are not in [0, ElementCount), in the
+	// order found. Only an invalid instance (see Validate) has them. They are
+	// otherwise ignored.
+	InvalidElements []int
+	// The sum of the costs of the subsets.
+	Cost float64
+	// If each element is covered by exactly one subset and all indices and
+	// elements are valid. This has the same meaning as SubsetsEval.ExactlyCovered.
+	ExactlyCovered bool
+}
+
+// Verify recomputes the coverage and cost of the subsets with the indices
+// independently of any solver.
+func Verify(ins Instance, indices []int) Verification {
+	v := Verification{Coverage: make([]int, ins.ElementCount)}
+	seen := make(map[int]bool, len(indices))
+	for _, j := range indices {
+		if j < 0 || j >= len(ins.Subsets) {
+			v.InvalidIndices = append(v.InvalidIndices, j)
+			continue
+		}
+		if seen[j] {
+			v.DuplicateIndices = append(v.DuplicateIndices, j)
+		}
+		seen[j] = true
+		v.Cost += ins.Costs[j]
+		for _, i := range ins.Subsets[j] {
+			if i < 0 || i >= ins.ElementCount {
+				v.InvalidElements = append(v.InvalidElements, i)
+				continue
+			}
+			v.Coverage[i]++
+		}
+	}
+
+	for i, count := range v.Coverage {
+		if count > 1 {
+			v.OverCovered = append(v.OverCovered, i)
+		} else if count == 0 {
+			v.UnderCovered = append(v.UnderCovered, i)
+		}
+	}
+	v.ExactlyCovered = len(v.OverCovered) == 0 && len(v.UnderCovered) == 0 &&
+		len(v.InvalidIndices) == 0 && len(v.InvalidElements) == 0
+	return v
+}
+
+// CostMatches reports if the cost equals the recomputed cost, see CostsEqual.
+func (v Verification) CostMatches(cost float64) bool {
+	return CostsEqual(cost, v.Cost)
+}
+
+// CostsEqual reports if the costs are equal up to a relative tolerance of
+// 1e-9. Costs of at most 1 in magnitude are compared with an absolute
+// tolerance of 1e-9 instead.
+func CostsEqual(a, b float64) bool {
+	return math.Abs(a-b) <= costTolerance*max(1, math.Abs(a), math.Abs(b))
+}
@@ -0,0 +1,58 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestVerify(t *testing.T) {
+	// Subsets {0, 1}, {2} and {0, 1, 2} with costs 1.5, 2 and 4.
+	ins := mpsTestInstance
+
+	v := Verify(ins, []int{0, 1})
+	assert.DeepEqual(t, v, Verification{Coverage: []int{1, 1, 1}, Cost: 3.5, ExactlyCovered: true})
+	assert.Assert(t, v.CostMatches(3.5+1e-12))
+	assert.Assert(t, !v.CostMatches(3.6))
+	assert.Assert(t, CostsEqual(1e12, 1e12+1))
+	assert.Assert(t, !CostsEqual(0, 1e-8))
+
+	v = Verify(ins, []int{2, 0, 5, 0, -1})
+	assert.DeepEqual(t, v, Verification{
+		Coverage:         []int{3, 3, 1},
+		OverCovered:      []int{0, 1},
+		InvalidIndices:   []int{5, -1},
+		DuplicateIndices: []int{0},
+		Cost:             7,
+	})
+
+	// Verify must not panic on an invalid instance.
+	invalid := Instance{ElementCount: 2, Subsets: [][]int{{0}, {-1, 1, 5}}, Costs: []float64{1, 1}}
+	v = Verify(invalid, []int{1})
+	assert.DeepEqual(t, v, Verification{
+		Coverage:        []int{0, 1},
+		UnderCovered:    []int{0},
+		InvalidElements: []int{-1, 5},
+		Cost:            1,
+	})
+
+	v = Verify(ins, nil)
+	assert.DeepEqual(t, v, Verification{Coverage: []int{0, 0, 0}, UnderCovered: []int{0, 1, 2}})
+}
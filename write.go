@@ -0,0 +1,57 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteJSON writes the instance as compact JSON, which can be read by
+// ReadJSON.
+func WriteJSON(w io.Writer, ins Instance) error {
+	// Avoid null for the zero Instance.
+	if ins.Subsets == nil {
+		ins.Subsets = make([][]int, 0)
+	}
+	if ins.Costs == nil {
+		ins.Costs = make([]float64, 0)
+	}
+	return json.NewEncoder(w).Encode(ins)
+}
+
+// WriteFormat writes the instance in the format. The OR-Library formats have
+// no names so the names are lost.
+func WriteFormat(w io.Writer, ins Instance, format Format) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, ins)
+	case FormatMPS:
+		return WriteMPS(w, ins)
+	case FormatORLibSCP:
+		return WriteORLibSCP(w, ins)
+	case FormatORLibSPP:
+		return WriteORLibSPP(w, ins)
+	case FormatBinary:
+		return WriteBinary(w, ins)
+	case FormatLP:
+		return WriteLP(w, ins)
+	}
+	return fmt.Errorf("unable to write the format %v", format)
+}
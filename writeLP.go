@@ -0,0 +1,138 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LP lines are limited to 255 characters by some readers so longer
+// expressions are continued on the next line.
+const maxLPLineLength = 200
+
+// WriteLP writes the instance as a binary set partitioning model in the
+// CPLEX LP format. The rows and columns are named using ElementName and
+// SubsetName.
+func WriteLP(w io.Writer, ins Instance) error {
+	return WriteLPFormulation(w, ins, SetPartitioning)
+}
+
+// WriteLPFormulation writes the instance as a binary model of the
+// formulation in the CPLEX LP format. See WriteLP.
+func WriteLPFormulation(w io.Writer, ins Instance, formulation Formulation) error {
+	if err := checkNamesForWriting(ins, isValidLPName); err != nil {
+		return err
+	}
+	if ins.ElementCount > 0 && len(ins.Subsets) == 0 {
+		return errors.New("an instance with elements but no subsets can not be written in the LP format")
+	}
+	sense := "="
+	if formulation == SetCovering {
+		sense = ">="
+	}
+
+	// The LP format is row oriented, so collect the subsets of each element.
+	elementSubsets := make([][]int, ins.ElementCount)
+	for j, subset := range ins.Subsets {
+		for _, i := range subset {
+			elementSubsets[i] = append(elementSubsets[i], j)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	lw := lpLineWriter{w: bw}
+	fmt.Fprintln(bw, `\ cover instance`)
+	fmt.Fprintln(bw, "Minimize")
+	lw.start(" " + uniqueObjectiveName(ins, "obj") + ":")
+	for j := range ins.Subsets {
+		term := formatFloat(ins.Costs[j]) + " " + ins.SubsetName(j)
+		if j > 0 {
+			term = "+ " + term
+		}
+		lw.add(term)
+	}
+	lw.end()
+
+	fmt.Fprintln(bw, "Subject To")
+	for i, subsets := range elementSubsets {
+		lw.start(" " + ins.ElementName(i) + ":")
+		for k, j := range subsets {
+			term := ins.SubsetName(j)
+			if k > 0 {
+				term = "+ " + term
+			}
+			lw.add(term)
+		}
+		if len(subsets) == 0 {
+			// An element in no subset makes the instance infeasible. Express
+			// it with an empty left-hand side.
+			lw.add("0 " + ins.SubsetName(0))
+		}
+		lw.add(sense + " 1")
+		lw.end()
+	}
+
+	fmt.Fprintln(bw, "Binary")
+	lw.start("")
+	for j := range ins.Subsets {
+		lw.add(ins.SubsetName(j))
+	}
+	lw.end()
+	fmt.Fprintln(bw, "End")
+
+	return bw.Flush()
+}
+
+// lpLineWriter writes space separated terms and breaks long lines.
+type lpLineWriter struct {
+	w          *bufio.Writer
+	lineLength int
+}
+
+func (lw *lpLineWriter) start(s string) {
+	lw.w.WriteString(s)
+	lw.lineLength = len(s)
+}
+
+func (lw *lpLineWriter) add(term string) {
+	if lw.lineLength+1+len(term) > maxLPLineLength {
+		lw.w.WriteString("\n ")
+		lw.lineLength = 1
+	}
+	lw.w.WriteString(" ")
+	lw.w.WriteString(term)
+	lw.lineLength += 1 + len(term)
+}
+
+func (lw *lpLineWriter) end() {
+	lw.w.WriteString("\n")
+	lw.lineLength = 0
+}
+
+// isValidLPName checks that the name can be used in the CPLEX LP format
+// where names can not start with a digit or period or contain operators.
+func isValidLPName(name string) bool {
+	if name == "" || len(name) > 255 || strings.ContainsAny(name[:1], "0123456789.") {
+		return false
+	}
+	return !strings.ContainsAny(name, " \t\r\n+-*/^<>=:[]\\")
+}
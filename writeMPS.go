@@ -0,0 +1,146 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Formulation is the kind of binary ILP model written for an instance.
+type Formulation int
+
+const (
+	// Every element must be covered exactly once: min cx s.t. Ax = 1.
+	SetPartitioning Formulation = iota
+	// Every element must be covered at least once: min cx s.t. Ax >= 1.
+	SetCovering
+)
+
+// WriteMPS writes the instance as a binary set partitioning model in free
+// MPS format. The rows and columns are named using ElementName and
+// SubsetName. The output can be read by ReadMPSInstance.
+func WriteMPS(w io.Writer, ins Instance) error {
+	return WriteMPSFormulation(w, ins, SetPartitioning)
+}
+
+// WriteMPSFormulation writes the instance as a binary model of the
+// formulation in free MPS format. See WriteMPS.
+func WriteMPSFormulation(w io.Writer, ins Instance, formulation Formulation) error {
+	if err := checkNamesForWriting(ins, isValidMPSName); err != nil {
+		return err
+	}
+	sense := "E"
+	if formulation == SetCovering {
+		sense = "G"
+	}
+
+	objective := uniqueObjectiveName(ins, "COST")
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "NAME cover")
+	fmt.Fprintln(bw, "ROWS")
+	fmt.Fprintf(bw, " N %s\n", objective)
+	for i := 0; i < ins.ElementCount; i++ {
+		fmt.Fprintf(bw, " %s %s\n", sense, ins.ElementName(i))
+	}
+
+	// MPS is column oriented, so each subset is written as is.
+	fmt.Fprintln(bw, "COLUMNS")
+	fmt.Fprintln(bw, "    MARKER 'MARKER' 'INTORG'")
+	for j, subset := range ins.Subsets {
+		name := ins.SubsetName(j)
+		fmt.Fprintf(bw, "    %s %s %s\n", name, objective, formatFloat(ins.Costs[j]))
+		for _, i := range subset {
+			fmt.Fprintf(bw, "    %s %s 1\n", name, ins.ElementName(i))
+		}
+	}
+	fmt.Fprintln(bw, "    MARKER 'MARKER' 'INTEND'")
+
+	fmt.Fprintln(bw, "RHS")
+	for i := 0; i < ins.ElementCount; i++ {
+		fmt.Fprintf(bw, "    RHS %s 1\n", ins.ElementName(i))
+	}
+
+	fmt.Fprintln(bw, "BOUNDS")
+	for j := range ins.Subsets {
+		fmt.Fprintf(bw, " BV BND %s\n", ins.SubsetName(j))
+	}
+	fmt.Fprintln(bw, "ENDATA")
+
+	return bw.Flush()
+}
+
+// formatFloat formats x with the fewest digits that still read back as x.
+func formatFloat(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
+
+// isValidMPSName reports if the name can be written as a row or column name.
+// 'MARKER' is not since it would be read as an integer marker.
+func isValidMPSName(name string) bool {
+	return name != "" && name != "'MARKER'" && !strings.ContainsAny(name, " \t\r\n")
+}
+
+// checkNamesForWriting validates the instance and checks that the names of
+// the elements and the subsets are valid. The element names must be unique
+// among themselves and so must the subset names, but an element and a subset
+// may share a name since rows and columns have separate namespaces in both
+// the MPS and the LP formats.
+func checkNamesForWriting(ins Instance, isValid func(string) bool) error {
+	if err := Validate(ins); err != nil {
+		return err
+	}
+
+	check := func(kind string, count int, nameOf func(int) string) error {
+		seen := make(map[string]struct{}, count)
+		for idx := 0; idx < count; idx++ {
+			name := nameOf(idx)
+			if !isValid(name) {
+				return fmt.Errorf("the name %q of %s %d can not be written", name, kind, idx)
+			}
+			if _, found := seen[name]; found {
+				return fmt.Errorf("the name %q of %s %d is not unique", name, kind, idx)
+			}
+			seen[name] = struct{}{}
+		}
+		return nil
+	}
+
+	if err := check("element", ins.ElementCount, ins.ElementName); err != nil {
+		return err
+	}
+	return check("subset", len(ins.Subsets), ins.SubsetName)
+}
+
+// uniqueObjectiveName returns name with "_" appended until it is not the
+// name of an element.
+func uniqueObjectiveName(ins Instance, name string) string {
+	elementNames := make(map[string]struct{}, ins.ElementCount)
+	for i := 0; i < ins.ElementCount; i++ {
+		elementNames[ins.ElementName(i)] = struct{}{}
+	}
+	for {
+		if _, found := elementNames[name]; !found {
+			return name
+		}
+		name += "_"
+	}
+}
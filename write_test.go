@@ -0,0 +1,114 @@
+/*
+ Copyright (C) 2025 Douglas Wayne Potter
+
+ This program is free software: you can redistribute it and/or modify
+ it under the terms of the GNU Affero General Public License as
+ published by the Free Software Foundation, either version 3 of the
+ License, or (at your option) any later version.
+
+ This program is distributed in the hope that it will be useful,
+ but WITHOUT ANY WARRANTY; without even the implied warranty of
+ MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ GNU Affero General Public License for more details.
+
+ You should have received a copy of the GNU Affero General Public License
+ along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cover
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWriteMPSRoundTrip(t *testing.T) {
+	var b bytes.Buffer
+	assert.NilError(t, WriteMPS(&b, mpsTestInstance))
+	ins, err := ReadMPS(&b)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *ins, mpsTestInstance)
+}
+
+func TestWriteMPSRoundTripRandomInstance(t *testing.T) {
+	original := MakeRandomInstance(10, 40, 1000, 7)
+	var b bytes.Buffer
+	assert.NilError(t, WriteMPS(&b, original))
+	ins, err := ReadMPS(&b)
+	assert.NilError(t, err)
+
+	assert.Equal(t, ins.ElementCount, original.ElementCount)
+	assert.DeepEqual(t, ins.Subsets, original.Subsets)
+	assert.DeepEqual(t, ins.Costs, original.Costs)
+	assert.Equal(t, ins.ElementNames[3], "r3")
+	assert.Equal(t, ins.SubsetNames[5], "c5")
+}
+
+func TestWriteMPSObjectiveNameIsUnique(t *testing.T) {
+	ins := Instance{
+		ElementCount: 1,
+		Subsets:      [][]int{{0}},
+		Costs:        []float64{1},
+		ElementNames: []string{"COST"},
+	}
+	var b bytes.Buffer
+	assert.NilError(t, WriteMPS(&b, ins))
+	assert.Assert(t, strings.Contains(b.String(), " N COST_\n"))
+	read, err := ReadMPS(&b)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, read.ElementNames, []string{"COST"})
+}
+
+func TestWriteMPSInvalidNames(t *testing.T) {
+	ins := mpsTestInstance
+	ins.SubsetNames = []string{"X1", "X1", "X3"}
+	assert.ErrorContains(t, WriteMPS(&bytes.Buffer{}, ins), `the name "X1" of subset 1 is not unique`)
+	ins.SubsetNames = []string{"X1", "X 2", "X3"}
+	assert.ErrorContains(t, WriteMPS(&bytes.Buffer{}, ins), `the name "X 2" of subset 1 can not be written`)
+	ins.SubsetNames = []string{"X1", "'MARKER'", "X3"}
+	assert.ErrorContains(t, WriteMPS(&bytes.Buffer{}, ins), `the name "'MARKER'" of subset 1 can not be written`)
+}
+
+func TestWriteSharedElementAndSubsetNames(t *testing.T) {
+	// Rows and columns have separate namespaces.
+	ins := mpsTestInstance
+	ins.SubsetNames = []string{"R1", "R2", "R3"}
+
+	var b bytes.Buffer
+	assert.NilError(t, WriteMPS(&b, ins))
+	read, err := ReadMPS(&b)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *read, ins)
+
+	b.Reset()
+	assert.NilError(t, WriteLP(&b, ins))
+	assert.Assert(t, strings.Contains(b.String(), " R1: R1 + R3 = 1\n"), b.String())
+}
+
+func TestWriteLP(t *testing.T) {
+	var b bytes.Buffer
+	assert.NilError(t, WriteLPFormulation(&b, mpsTestInstance, SetCovering))
+	assert.Equal(t, b.String(), `\ cover instance
+Minimize
+ obj: 1.5 X1 + 2 X2 + 4 X3
+Subject To
+ R1: X1 + X3 >= 1
+ R2: X1 + X3 >= 1
+ R3: X2 + X3 >= 1
+Binary
+ X1 X2 X3
+End
+`)
+}
+
+func TestWriteLPBreaksLongLines(t *testing.T) {
+	ins := MakeRandomInstance(5, 30, 1, 3)
+	var b bytes.Buffer
+	assert.NilError(t, WriteLP(&b, ins))
+	for _, line := range strings.Split(b.String(), "\n") {
+		assert.Assert(t, len(line) <= maxLPLineLength, line)
+	}
+}